@@ -0,0 +1,120 @@
+// Package aerrorstest provides test helpers for aerrors' errors.
+package aerrorstest
+
+import (
+	"testing"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// AssertIs reports an error if `err` does not match `target` by aerrors.Is, that is errors.Is detecting cycles.
+func AssertIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if !aerrors.Is(err, target) {
+		t.Errorf("error is not %v\n  got: %v", target, err)
+		return false
+	}
+	return true
+}
+
+// AssertNotIs reports an error if `err` matches `target` by aerrors.Is.
+func AssertNotIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	if aerrors.Is(err, target) {
+		t.Errorf("error is %v\n  got: %v", target, err)
+		return false
+	}
+	return true
+}
+
+// AssertErr reports an error if `err` has no *aerrors.Err, and returns it.
+func AssertErr(t testing.TB, err error) (*aerrors.Err, bool) {
+	t.Helper()
+	e, ok := aerrors.AsErr(err)
+	if !ok {
+		t.Errorf("error is not *aerrors.Err\n  got: %#v", err)
+	}
+	return e, ok
+}
+
+// AssertPriority reports an error if the priority of the first *aerrors.Err in `err` is not `want`.
+func AssertPriority(t testing.TB, err error, want aerrors.ErrorPriority) bool {
+	t.Helper()
+	e, ok := AssertErr(t, err)
+	if !ok {
+		return false
+	}
+	if got := e.Priority(); got != want {
+		t.Errorf("priority of %v == %v, want %v", err, got, want)
+		return false
+	}
+	return true
+}
+
+// CodeLabel is the label of the Value read as the error code by AssertCode.
+const CodeLabel = "code"
+
+// AssertCode reports an error if the code of `err` is not `want`.
+//
+// The code is returned by the first error in `err` implementing `Code() string`,
+// or the Value labeled CodeLabel if no error implements it.
+func AssertCode(t testing.TB, err error, want string) bool {
+	t.Helper()
+	got, ok := lookupCode(err)
+	if !ok {
+		t.Errorf("%v has no code", err)
+		return false
+	}
+	if got != want {
+		t.Errorf("code of %v == %q, want %q", err, got, want)
+		return false
+	}
+	return true
+}
+
+func lookupCode(err error) (string, bool) {
+	var coder interface{ Code() string }
+	aerrors.Walk(err, func(err error) bool {
+		coder, _ = err.(interface{ Code() string })
+		return coder == nil
+	})
+	if coder != nil {
+		return coder.Code(), true
+	}
+	return lookupValue(err, CodeLabel)
+}
+
+// AssertValue reports an error if the value labeled `label` is not `want`.
+//
+// Values are looked up by aerrors.ValueOf and compared by Text, so lazy values are evaluated.
+func AssertValue(t testing.TB, err error, label, want string) bool {
+	t.Helper()
+	got, ok := lookupValue(err, label)
+	if !ok {
+		t.Errorf("%v has no value labeled %q", err, label)
+		return false
+	}
+	if got != want {
+		t.Errorf("value %q of %v == %q, want %q", label, err, got, want)
+		return false
+	}
+	return true
+}
+
+// AssertNoValue reports an error if `err` has the value labeled `label`.
+func AssertNoValue(t testing.TB, err error, label string) bool {
+	t.Helper()
+	if got, ok := lookupValue(err, label); ok {
+		t.Errorf("%v has value labeled %q: %q", err, label, got)
+		return false
+	}
+	return true
+}
+
+func lookupValue(err error, label string) (string, bool) {
+	v, ok := aerrors.ValueOf(err, label)
+	if !ok {
+		return "", false
+	}
+	return v.Text(), true
+}
@@ -0,0 +1,113 @@
+package aerrorstest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kamiaka/aerrors"
+)
+
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Fatal(args ...interface{}) {
+	panic(fmt.Sprint(args...))
+}
+
+type codeError string
+
+func (e codeError) Error() string { return "code error" }
+func (e codeError) Code() string  { return string(e) }
+
+func TestAssertions(t *testing.T) {
+	appError := aerrors.New("app error")
+	other := errors.New("other")
+	err := fmt.Errorf("wrapped: %w", appError.New("oops", aerrors.Priority(aerrors.Warning)).WithString("user_id", "42"))
+	coded := fmt.Errorf("wrapped: %w", appError.New("oops").WithString(CodeLabel, "E42"))
+	cycle := aerrors.New("cycle")
+	cycle.WithError(cycle)
+
+	cases := []struct {
+		assert  func(t testing.TB) bool
+		wantErr string
+	}{
+		{
+			assert: func(t testing.TB) bool { return AssertIs(t, err, appError) },
+		},
+		{
+			assert:  func(t testing.TB) bool { return AssertIs(t, err, other) },
+			wantErr: "error is not other",
+		},
+		{
+			assert: func(t testing.TB) bool { return AssertNotIs(t, err, other) },
+		},
+		{
+			assert: func(t testing.TB) bool { return AssertPriority(t, err, aerrors.Warning) },
+		},
+		{
+			assert:  func(t testing.TB) bool { return AssertPriority(t, err, aerrors.Error) },
+			wantErr: "priority of wrapped: oops == Warning, want Error",
+		},
+		{
+			assert:  func(t testing.TB) bool { return AssertPriority(t, other, aerrors.Error) },
+			wantErr: "error is not *aerrors.Err",
+		},
+		{
+			assert: func(t testing.TB) bool { return AssertValue(t, err, "user_id", "42") },
+		},
+		{
+			assert:  func(t testing.TB) bool { return AssertValue(t, err, "user_id", "1") },
+			wantErr: `value "user_id" of wrapped: oops == "42", want "1"`,
+		},
+		{
+			assert:  func(t testing.TB) bool { return AssertValue(t, err, "id", "42") },
+			wantErr: `wrapped: oops has no value labeled "id"`,
+		},
+		{
+			assert: func(t testing.TB) bool { return AssertNoValue(t, err, "id") },
+		},
+		{
+			assert: func(t testing.TB) bool { return AssertCode(t, coded, "E42") },
+		},
+		{
+			assert: func(t testing.TB) bool { return AssertNotIs(t, cycle, other) },
+		},
+		{
+			assert:  func(t testing.TB) bool { return AssertCode(t, cycle, "E42") },
+			wantErr: "cycle has no code",
+		},
+		{
+			assert:  func(t testing.TB) bool { return AssertCode(t, coded, "E1") },
+			wantErr: `code of wrapped: oops == "E42", want "E1"`,
+		},
+		{
+			assert: func(t testing.TB) bool { return AssertCode(t, fmt.Errorf("wrapped: %w", codeError("E7")), "E7") },
+		},
+		{
+			assert:  func(t testing.TB) bool { return AssertCode(t, err, "E42") },
+			wantErr: "wrapped: oops has no code",
+		},
+	}
+
+	for i, tc := range cases {
+		ft := &fakeT{}
+		ok := tc.assert(ft)
+		if ok != (tc.wantErr == "") {
+			t.Errorf("#%d: assertion returns %v", i, ok)
+		}
+		got := strings.Join(ft.errors, "\n")
+		if tc.wantErr == "" && got != "" || !strings.HasPrefix(got, tc.wantErr) {
+			t.Errorf("#%d: error == %#v, want %#v", i, got, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package aerrorstest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// UpdateEnv is the environment variable that enables Update when it is set to non-empty, e.g. `AERRORS_UPDATE=1 go test`.
+const UpdateEnv = "AERRORS_UPDATE"
+
+// Update golden files instead of comparing.
+//
+// It is initialized by UpdateEnv. Tests can also set it by their own flag, e.g. `aerrorstest.Update = *update`.
+var Update = os.Getenv(UpdateEnv) != ""
+
+var (
+	locationPattern = regexp.MustCompile(`[^\s:,]*?([^\s:,/]+\.go):\d+`)
+	timePattern     = regexp.MustCompile(`(\btime[^\w\n]*)\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(\.\d+)?(Z|[+-]\d\d:\d\d)`)
+	idPattern       = regexp.MustCompile(`(\berror_id[^\w\n]*)[0-9A-HJKMNP-TV-Z]{26}\b`)
+)
+
+// Normalize replaces file paths and line numbers, times and error IDs in `s`, e.g.,
+// "/path/to/pkg/file.go:42" is replaced by "file.go:_", and "time: 2001-02-03T04:05:06Z" by "time: _".
+//
+// Only the time and the ID labeled "time" and "error_id" are replaced, with any label separator of NewFormatter, e.g. "time=".
+func Normalize(s string) string {
+	s = locationPattern.ReplaceAllString(s, "${1}:_")
+	s = timePattern.ReplaceAllString(s, "${1}_")
+	return idPattern.ReplaceAllString(s, "${1}_")
+}
+
+// AssertGolden reports an error if normalized `%+v` output of `err` differs from the golden file `name`.
+//
+// If Update is true, the golden file is written instead.
+func AssertGolden(t testing.TB, err error, name string) bool {
+	t.Helper()
+	got := Normalize(fmt.Sprintf("%+v", err)) + "\n"
+
+	if Update {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return true
+	}
+
+	want, rerr := os.ReadFile(name)
+	if rerr != nil {
+		t.Errorf("read golden file: %v", rerr)
+		return false
+	}
+	if got != string(want) {
+		t.Errorf("%%+v of error differs from %s\n got:\n%s\nwant:\n%s", name, got, want)
+		return false
+	}
+	return true
+}
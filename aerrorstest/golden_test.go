@@ -0,0 +1,52 @@
+package aerrorstest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kamiaka/aerrors"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		s    string
+		want string
+	}{
+		{
+			s:    "callers: pkg.Func:/path/to/pkg/file.go:42, pkg.main:github.com/user/pkg/main.go:7",
+			want: "callers: pkg.Func:file.go:_, pkg.main:main.go:_",
+		},
+		{
+			s:    "time: 2001-02-03T04:05:06.000000007Z\n    error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV\n    at: 2001-02-03T13:05:06+09:00",
+			want: "time: _\n    error_id: _\n    at: 2001-02-03T13:05:06+09:00",
+		},
+		{
+			s:    "time=2001-02-03T04:05:06Z, error_id=01ARZ3NDEKTSV4RRFFQ69G5FAV, start_time=2001-02-03T04:05:06Z",
+			want: "time=_, error_id=_, start_time=2001-02-03T04:05:06Z",
+		},
+		{
+			s:    "no locations",
+			want: "no locations",
+		},
+	}
+
+	for i, tc := range cases {
+		if got := Normalize(tc.s); got != tc.want {
+			t.Errorf("#%d: Normalize(%#v) == %#v, want %#v", i, tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	err := aerrors.New("app error").Errorf("error: %w", errors.New("oops")).WithInt("id", 42)
+
+	AssertGolden(t, err, "testdata/error.golden")
+	if Update {
+		return
+	}
+
+	ft := &fakeT{}
+	if AssertGolden(ft, aerrors.New("other"), "testdata/error.golden") {
+		t.Errorf("AssertGolden() with different error == true, want false")
+	}
+}
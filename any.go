@@ -0,0 +1,221 @@
+package aerrors
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits of values converted by Any and Struct.
+var (
+	// MaxAnyDepth is the max depth of nested structs, maps and slices.
+	// Deeper values are rendered as DepthMarker.
+	MaxAnyDepth = 5
+	// MaxAnyLen is the max number of fields or elements converted for each struct, map and slice.
+	// The rest is summarized as the value labeled MoreLabel.
+	MaxAnyLen = 100
+)
+
+// MoreLabel is the label of the value summarizing fields or elements over MaxAnyLen, e.g. "...: 3 more".
+const MoreLabel = "..."
+
+// RedactedValue is the value of fields tagged `secret`.
+const RedactedValue = "[REDACTED]"
+
+// Any returns Value converted from `v` via reflection.
+//
+// Structs, maps, slices and arrays are converted to groups of their fields or elements recursively,
+// labeled by field names, map keys or indexes.
+// Durations, IP addresses, URLs and errors are converted to the Value of the kind,
+// fmt.Stringer is converted to the string, and other values are formatted by fmt.Sprint.
+//
+// Fields of structs are controlled by the tag `aerr:"name,omitempty,secret"`:
+// name renames the label ("-" omits the field), omitempty omits the zero value and secret redacts the value by RedactedValue.
+// Unexported fields are omitted.
+func Any(l string, v interface{}) *Value {
+	return anyValue(l, reflect.ValueOf(v), 0)
+}
+
+// Any appends Value converted via reflection and return Values.
+func (ls Values) Any(l string, v interface{}) Values {
+	return append(ls, Any(l, v))
+}
+
+// Struct returns Value converted from the struct or the pointer to the struct `v` in the same way as Any.
+// Values other than structs are rendered as "<not struct: T>", and nil pointers as "<nil>".
+func Struct(l string, v interface{}) *Value {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t != nil && t.Kind() != reflect.Struct {
+		return String(l, fmt.Sprintf("<not struct: %T>", v))
+	}
+	return Any(l, v)
+}
+
+// Struct appends Value converted from the struct and return Values.
+func (ls Values) Struct(l string, v interface{}) Values {
+	return append(ls, Struct(l, v))
+}
+
+func anyValue(l string, rv reflect.Value, depth int) *Value {
+	if !rv.IsValid() {
+		return String(l, "<nil>")
+	}
+	if rv.CanInterface() {
+		switch v := rv.Interface().(type) {
+		case time.Duration:
+			return Duration(l, v)
+		case net.IP:
+			return IP(l, v)
+		case netip.Addr:
+			return Addr(l, v)
+		case *url.URL:
+			return URL(l, v)
+		case error:
+			if isNil(rv) {
+				return String(l, "<nil>")
+			}
+			return ErrorValue(l, v)
+		case fmt.Stringer:
+			if isNil(rv) {
+				return String(l, "<nil>")
+			}
+			return String(l, v.String())
+		case []byte:
+			return Bytes(l, v)
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return String(l, "<nil>")
+		}
+		return anyValue(l, rv.Elem(), depth)
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		if depth >= MaxAnyDepth {
+			return String(l, DepthMarker)
+		}
+	default:
+		return String(l, fmt.Sprint(rv))
+	}
+
+	group := Group(l)
+	switch rv.Kind() {
+	case reflect.Struct:
+		t := rv.Type()
+		var fields []int
+		var tags []anyTag
+		for i := 0; i < rv.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag := parseAnyTag(f)
+			if tag.name == "-" || tag.omitEmpty && rv.Field(i).IsZero() {
+				continue
+			}
+			fields = append(fields, i)
+			tags = append(tags, tag)
+		}
+		for i, field := range fields {
+			if !group.addAnyMember(len(fields)) {
+				break
+			}
+			if tags[i].secret {
+				group.Group = append(group.Group, String(tags[i].name, RedactedValue))
+				continue
+			}
+			group.Group = append(group.Group, anyValue(tags[i].name, rv.Field(field), depth+1))
+		}
+	case reflect.Map:
+		if rv.IsNil() {
+			return String(l, "<nil>")
+		}
+		keys := rv.MapKeys()
+		labels := make([]string, len(keys))
+		for i, k := range keys {
+			labels[i] = fmt.Sprint(k.Interface())
+		}
+		sort.Sort(mapKeys{keys, labels})
+		for i, k := range keys {
+			if !group.addAnyMember(len(keys)) {
+				break
+			}
+			group.Group = append(group.Group, anyValue(labels[i], rv.MapIndex(k), depth+1))
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return String(l, "<nil>")
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if !group.addAnyMember(rv.Len()) {
+				break
+			}
+			group.Group = append(group.Group, anyValue(strconv.Itoa(i), rv.Index(i), depth+1))
+		}
+	}
+	return group
+}
+
+// addAnyMember returns whether the next member can be added to the group has `total` members.
+// If not, it appends the value summarizing the rest members.
+func (v *Value) addAnyMember(total int) bool {
+	if len(v.Group) < MaxAnyLen {
+		return true
+	}
+	v.Group = append(v.Group, String(MoreLabel, fmt.Sprintf("%d more", total-MaxAnyLen)))
+	return false
+}
+
+func isNil(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return rv.IsNil()
+	}
+	return false
+}
+
+type anyTag struct {
+	name      string
+	omitEmpty bool
+	secret    bool
+}
+
+func parseAnyTag(f reflect.StructField) anyTag {
+	tag := anyTag{name: f.Name}
+	opts := strings.Split(f.Tag.Get("aerr"), ",")
+	if opts[0] != "" {
+		tag.name = opts[0]
+	}
+	for _, opt := range opts[1:] {
+		switch opt {
+		case "omitempty":
+			tag.omitEmpty = true
+		case "secret":
+			tag.secret = true
+		}
+	}
+	return tag
+}
+
+// mapKeys sorts map keys by their labels.
+type mapKeys struct {
+	keys   []reflect.Value
+	labels []string
+}
+
+func (m mapKeys) Len() int           { return len(m.keys) }
+func (m mapKeys) Less(i, j int) bool { return m.labels[i] < m.labels[j] }
+func (m mapKeys) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.labels[i], m.labels[j] = m.labels[j], m.labels[i]
+}
@@ -0,0 +1,109 @@
+package aerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type anyRequest struct {
+	ID       int               `aerr:"id"`
+	User     *anyUser          `aerr:"user"`
+	Tags     []string          `aerr:"tags,omitempty"`
+	Headers  map[string]string `aerr:"headers,omitempty"`
+	Password string            `aerr:"password,secret"`
+	Internal string            `aerr:"-"`
+	private  string
+}
+
+type anyUser struct {
+	Name  string
+	Email string `aerr:",omitempty"`
+}
+
+func ExampleStruct() {
+	req := &anyRequest{
+		ID:       42,
+		User:     &anyUser{Name: "alice"},
+		Headers:  map[string]string{"b": "2", "a": "1"},
+		Password: "p@ssw0rd",
+		Internal: "internal",
+	}
+	err := New("bad request", CallerDepth(0), Clock(testClock), GenerateID(testIDGenerator)).WithStruct("request", req)
+
+	b, _ := json.Marshal(err)
+	fmt.Println(string(b))
+	// Output:
+	// {"message":"bad request","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"request":{"id":"42","user":{"Name":"alice"},"headers":{"a":"1","b":"2"},"password":"[REDACTED]"}}}
+}
+
+type anyNode struct {
+	Next *anyNode
+}
+
+func TestAny(t *testing.T) {
+	tmpDepth, tmpLen := MaxAnyDepth, MaxAnyLen
+	MaxAnyDepth, MaxAnyLen = 2, 2
+	defer func() {
+		MaxAnyDepth, MaxAnyLen = tmpDepth, tmpLen
+	}()
+
+	node := &anyNode{}
+	node.Next = node
+	var nilErr *Err
+
+	cases := []struct {
+		value *Value
+		want  []string
+	}{
+		{value: Any("v", nil), want: []string{"v: <nil>"}},
+		{value: Any("v", 42), want: []string{"v: 42"}},
+		{value: Any("v", errors.New("oops")), want: []string{"v: oops"}},
+		{value: Any("v", nilErr), want: []string{"v: <nil>"}},
+		{value: Any("v", time.Duration(1500)*time.Millisecond), want: []string{"v: 1.5s"}},
+		{value: Any("v", []byte("foo")), want: []string{"v: 0x666f6f"}},
+		{value: Any("v", []int{1, 2, 3, 4}), want: []string{"v.0: 1", "v.1: 2", "v....: 2 more"}},
+		{value: Any("v", [][]int{{1}}), want: []string{"v.0.0: 1"}},
+		{value: Any("v", [][][]int{{{1}}}), want: []string{"v.0.0: <max depth>"}},
+		{value: Any("v", node), want: []string{"v.Next.Next: <max depth>"}},
+		{value: Any("v", map[int]bool{2: true, 1: false}), want: []string{"v.1: false", "v.2: true"}},
+		{value: Any("v", map[string]int(nil)), want: []string{"v: <nil>"}},
+		{value: Any("v", anyUser{}), want: []string{"v.Name: "}},
+	}
+
+	for i, tc := range cases {
+		var got []string
+		for _, v := range tc.value.Flatten() {
+			got = append(got, v.String())
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("#%d: Any()\ngot:  %#v\nwant: %#v", i, got, tc.want)
+		}
+	}
+}
+
+func TestStruct(t *testing.T) {
+	user := anyUser{Name: "alice"}
+	var nilUser *anyUser
+
+	cases := []struct {
+		value interface{}
+		want  string
+	}{
+		{value: user, want: "user.Name: alice"},
+		{value: &user, want: "user.Name: alice"},
+		{value: nilUser, want: "user: <nil>"},
+		{value: nil, want: "user: <nil>"},
+		{value: 42, want: "user: <not struct: int>"},
+		{value: map[string]string{"Name": "alice"}, want: "user: <not struct: map[string]string>"},
+	}
+
+	for i, tc := range cases {
+		if got := Struct("user", tc.value).String(); got != tc.want {
+			t.Errorf("#%d: Struct(%#v) = %q, want %q", i, tc.value, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package aerrors
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BytesMode is the mode rendering bytes Values.
+type BytesMode int
+
+// Modes rendering bytes Values.
+const (
+	// BytesHex renders bytes as hex with prefix "0x", e.g. "0x666f6f".
+	BytesHex BytesMode = iota
+	// BytesBase64 renders bytes as standard base64, e.g. "Zm9v".
+	BytesBase64
+	// BytesHexdump renders bytes as multi-line `hexdump -C` style.
+	BytesHexdump
+	// BytesText renders bytes as string if it is printable UTF-8, otherwise as BytesHex.
+	BytesText
+)
+
+// BytesFormat is the format rendering bytes Values.
+//
+// If MaxLen is positive, bytes over MaxLen are truncated and rendered as "... (N more bytes)".
+// The zero value renders whole bytes as hex.
+type BytesFormat struct {
+	Mode   BytesMode
+	MaxLen int
+}
+
+// Format returns rendered `b`.
+func (f BytesFormat) Format(b []byte) string {
+	more := 0
+	if f.MaxLen > 0 && len(b) > f.MaxLen {
+		more = len(b) - f.MaxLen
+		b = b[:f.MaxLen]
+	}
+
+	var s string
+	switch f.Mode {
+	case BytesBase64:
+		s = base64.StdEncoding.EncodeToString(b)
+	case BytesHexdump:
+		s = strings.TrimSuffix(hex.Dump(b), "\n")
+		if more > 0 {
+			s += "\n"
+		}
+	case BytesText:
+		if more > 0 {
+			// cut the incomplete rune at the end of the truncated bytes.
+			for i := 0; i < utf8.UTFMax-1 && len(b) > 0 && !utf8.Valid(b); i++ {
+				more++
+				b = b[:len(b)-1]
+			}
+		}
+		if printable(b) {
+			s = string(b)
+			break
+		}
+		s = formatHex(b)
+	default:
+		s = formatHex(b)
+	}
+
+	if more > 0 {
+		s += fmt.Sprintf("... (%d more bytes)", more)
+	}
+	return s
+}
+
+func formatHex(b []byte) string {
+	buf := hexPrefix
+	for _, c := range b {
+		buf = append(buf, digits[c/16], digits[c%16])
+	}
+	return string(buf)
+}
+
+func printable(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// BytesAs returns bytes Value rendered by `f`.
+// Unlike Bytes, the Value is not rendered by the BytesFormat of the config.
+func BytesAs(l string, v []byte, f BytesFormat) *Value {
+	return &Value{
+		Label:      l,
+		Value:      f.Format(v),
+		Kind:       KindBytes,
+		bytesFixed: true,
+	}
+}
+
+// BytesAs appends bytes Value rendered by `f` and return Values.
+func (ls Values) BytesAs(l string, v []byte, f BytesFormat) Values {
+	return append(ls, BytesAs(l, v, f))
+}
+
+// WithBytesAs appends bytes Value rendered by `f` and returns receiver.
+func (e *Err) WithBytesAs(l string, v []byte, f BytesFormat) *Err {
+	return e.addValues(BytesAs(l, v, f))
+}
+
+// apply returns `v` with bytes Values rendered by `f`, including values in groups.
+// Rendered Values drop the bytes, so they are not rendered again,
+// and lazy bytes Values are rendered by `f` when they are evaluated. The receiver is not modified.
+func (f BytesFormat) apply(v *Value) *Value {
+	switch {
+	case v.Kind == KindBytes && !v.bytesFixed:
+		rendered := *v
+		rendered.bytesFixed = true
+		if v.lazy != nil && v.lazy.bytes != nil {
+			rendered.lazy = newLazyBytes(v.lazy.bytes, f)
+			return &rendered
+		}
+		rendered.Value = f.Format(v.bytes)
+		rendered.lazy = nil
+		rendered.bytes = nil
+		return &rendered
+	case v.Kind == KindGroup:
+		var group []*Value
+		for i, g := range v.Group {
+			applied := f.apply(g)
+			if applied != g && group == nil {
+				group = append(make([]*Value, 0, len(v.Group)), v.Group[:i]...)
+			}
+			if group != nil {
+				group = append(group, applied)
+			}
+		}
+		if group == nil {
+			return v
+		}
+		rendered := *v
+		rendered.Group = group
+		return &rendered
+	}
+	return v
+}
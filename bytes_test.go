@@ -0,0 +1,91 @@
+package aerrors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleFormatBytes() {
+	err := New("bad payload", FormatBytes(BytesFormat{Mode: BytesHexdump, MaxLen: 20})).
+		WithBytes("payload", []byte("GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"))
+
+	fmt.Println(err.Values()[0].Text())
+	// Output:
+	// 00000000  47 45 54 20 2f 20 48 54  54 50 2f 31 2e 31 0d 0a  |GET / HTTP/1.1..|
+	// 00000010  48 6f 73 74                                       |Host|
+	// ... (17 more bytes)
+}
+
+func TestBytesFormat_Format(t *testing.T) {
+	cases := []struct {
+		format BytesFormat
+		bytes  []byte
+		want   string
+	}{
+		{format: BytesFormat{}, bytes: []byte("foo"), want: "0x666f6f"},
+		{format: BytesFormat{MaxLen: 2}, bytes: []byte("foo"), want: "0x666f... (1 more bytes)"},
+		{format: BytesFormat{MaxLen: 3}, bytes: []byte("foo"), want: "0x666f6f"},
+		{format: BytesFormat{Mode: BytesBase64}, bytes: []byte("foo"), want: "Zm9v"},
+		{format: BytesFormat{Mode: BytesBase64, MaxLen: 1}, bytes: []byte("foo"), want: "Zg==... (2 more bytes)"},
+		{format: BytesFormat{Mode: BytesText}, bytes: []byte("foo\tbar"), want: "foo\tbar"},
+		{format: BytesFormat{Mode: BytesText}, bytes: []byte{0xff, 0x00}, want: "0xff00"},
+		{format: BytesFormat{Mode: BytesText}, bytes: []byte("a\x00"), want: "0x6100"},
+		{format: BytesFormat{Mode: BytesText, MaxLen: 3}, bytes: []byte("aあ"), want: "a... (3 more bytes)"},
+		{format: BytesFormat{Mode: BytesHexdump}, bytes: []byte("foo"), want: "00000000  66 6f 6f                                          |foo|"},
+		{format: BytesFormat{Mode: BytesHexdump}, bytes: nil, want: ""},
+	}
+
+	for i, tc := range cases {
+		if got := tc.format.Format(tc.bytes); got != tc.want {
+			t.Errorf("#%d: Format(%q)\ngot:  %q\nwant: %q", i, tc.bytes, got, tc.want)
+		}
+	}
+}
+
+func TestErr_WithBytes_format(t *testing.T) {
+	f := BytesFormat{Mode: BytesBase64}
+	value := Bytes("raw", []byte("foo"))
+	err := New("error", FormatBytes(f)).
+		WithValue(value, Group("group", Any("any", []byte("foo")))).
+		WithBytesAs("fixed", []byte("foo"), BytesFormat{MaxLen: 1})
+
+	var got []string
+	for _, v := range flattenValues(err.Values()) {
+		got = append(got, v.String())
+	}
+	want := fmt.Sprint([]string{"raw: Zm9v", "group.any: Zm9v", "fixed: 0x66... (2 more bytes)"})
+	if fmt.Sprint(got) != want {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+	if value.Text() != "0x666f6f" || value.bytes == nil {
+		t.Errorf("added value is modified: %q", value.Text())
+	}
+	buf := []byte("foo")
+	bufValue := Bytes("buf", buf)
+	copy(buf, "bar")
+	if got := New("error", FormatBytes(f)).WithValue(bufValue).Values()[0].Text(); got != "Zm9v" {
+		t.Errorf("value of the mutated buffer = %q, want %q", got, "Zm9v")
+	}
+	if got := err.New("child").WithBytes("child", []byte("foo")).Values(); got[len(got)-1].Text() != "Zm9v" {
+		t.Errorf("child value = %q, want %q", got[len(got)-1].Text(), "Zm9v")
+	}
+}
+
+func TestErr_WithBytes_finalized(t *testing.T) {
+	err := New("error").WithBytes("payload", make([]byte, 1024))
+	if v := err.Values()[0]; v.bytes != nil || v.lazy != nil || len(v.Value) != 2+2*1024 {
+		t.Errorf("value is not finalized: bytes = %d, lazy = %v, len(Value) = %d", len(v.bytes), v.lazy != nil, len(v.Value))
+	}
+
+	called := 0
+	lazy := New("error", FormatBytes(BytesFormat{Mode: BytesBase64})).WithLazyBytes("lazy", func() []byte {
+		called++
+		return []byte("foo")
+	})
+	if called != 0 {
+		t.Errorf("lazy bytes are evaluated when added")
+	}
+	if got := lazy.Values()[0].Text(); got != "Zm9v" || called != 1 {
+		t.Errorf("lazy bytes = %q (called %d), want %q", got, called, "Zm9v")
+	}
+}
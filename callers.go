@@ -0,0 +1,138 @@
+package aerrors
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kamiaka/aerrors/internal/stack"
+)
+
+// PathRewriter rewrites the file path of callers and stack traces.
+// `function` is the package path-qualified function name of the frame.
+type PathRewriter func(file, function string) string
+
+// FullPath returns the file path as it is.
+// The path depends on the build environment unless built with `-trimpath`.
+func FullPath(file, function string) string {
+	return file
+}
+
+// BasePath returns the base name of the file, e.g., "file.go".
+func BasePath(file, function string) string {
+	return path.Base(file)
+}
+
+// PackagePath returns the package path and the base name of the file, e.g., "github.com/user/module/pkg/file.go".
+// It is the same as the path built with `-trimpath` for packages in modules.
+func PackagePath(file, function string) string {
+	return stack.PackagePath(function) + "/" + path.Base(file)
+}
+
+// ModuleRelativePath returns the path relative to the main module root, e.g., "pkg/file.go", for packages in the main module.
+// Otherwise it returns the same as PackagePath.
+//
+// Package main is relative to the module root found from the file path, that is the main module path built with `-trimpath`
+// or the directory has go.mod. If the root is not found, it returns the base name of the file.
+func ModuleRelativePath(file, function string) string {
+	pkg := stack.PackagePath(function)
+	mod := mainModulePath()
+	if pkg == "main" {
+		if mod != "" && strings.HasPrefix(file, mod+"/") {
+			return file[len(mod)+1:]
+		}
+		if root := moduleRoot(path.Dir(file)); root != "" {
+			return strings.TrimPrefix(file[len(root):], "/")
+		}
+		return path.Base(file)
+	}
+	if mod != "" && (pkg == mod || strings.HasPrefix(pkg, mod+"/")) {
+		return strings.TrimPrefix(pkg[len(mod):]+"/"+path.Base(file), "/")
+	}
+	return pkg + "/" + path.Base(file)
+}
+
+// moduleRoots caches module roots by directories.
+var moduleRoots sync.Map
+
+// moduleRoot returns the nearest ancestor of the directory `dir` has go.mod, or empty if not found.
+func moduleRoot(dir string) string {
+	if root, ok := moduleRoots.Load(dir); ok {
+		return root.(string)
+	}
+	root := ""
+	if path.IsAbs(dir) || filepath.IsAbs(dir) {
+		if _, err := os.Stat(filepath.Join(filepath.FromSlash(dir), "go.mod")); err == nil {
+			root = dir
+		} else if parent := path.Dir(dir); parent != dir {
+			root = moduleRoot(parent)
+		}
+	}
+	moduleRoots.Store(dir, root)
+	return root
+}
+
+var (
+	mainModuleOnce sync.Once
+	mainModule     string
+)
+
+func mainModulePath() string {
+	mainModuleOnce.Do(func() {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			mainModule = info.Main.Path
+		}
+	})
+	return mainModule
+}
+
+// TrimPrefixPath returns PathRewriter that removes the first matched prefix, e.g. GOPATH or module root.
+func TrimPrefixPath(prefixes ...string) PathRewriter {
+	return func(file, function string) string {
+		for _, prefix := range prefixes {
+			if prefix == "" {
+				continue
+			}
+			prefix = strings.TrimSuffix(prefix, "/") + "/"
+			if strings.HasPrefix(file, prefix) {
+				return file[len(prefix):]
+			}
+		}
+		return file
+	}
+}
+
+// callerFormat formats frames of callers and stack traces.
+type callerFormat struct {
+	path PathRewriter
+	line bool
+}
+
+func (f callerFormat) isDefault() bool {
+	return f.path == nil && f.line
+}
+
+func (f callerFormat) file(frame runtime.Frame) string {
+	if f.path == nil {
+		return frame.File
+	}
+	return f.path(frame.File, frame.Function)
+}
+
+func (f callerFormat) format(frames *stack.Frames) string {
+	if f.isDefault() {
+		return frames.String()
+	}
+	return frames.FormatFunc(", ", func(frame runtime.Frame) string {
+		s := stack.SimpleFunc(frame.Function) + ":" + f.file(frame)
+		if f.line {
+			s += ":" + strconv.Itoa(frame.Line)
+		}
+		return s
+	})
+}
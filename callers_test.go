@@ -0,0 +1,125 @@
+package aerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"runtime"
+	"testing"
+)
+
+func ExampleCallerPath() {
+	err := New("new error", CallerPath(ModuleRelativePath), CallerLine(false), Clock(testClock), GenerateID(testIDGenerator))
+
+	fmt.Printf("%+v", err)
+	// Output:
+	// new error:
+	//     priority: Error
+	//     callers: aerrors.ExampleCallerPath:callers_test.go
+	//     time: 2001-02-03T04:05:06.000000007Z
+	//     error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV
+}
+
+func TestPathRewriter(t *testing.T) {
+	cases := []struct {
+		rewriter PathRewriter
+		file     string
+		function string
+		want     string
+	}{
+		{
+			rewriter: FullPath,
+			file:     "/home/user/go/src/github.com/kamiaka/aerrors/retry/retry.go",
+			function: "github.com/kamiaka/aerrors/retry.Do",
+			want:     "/home/user/go/src/github.com/kamiaka/aerrors/retry/retry.go",
+		},
+		{
+			rewriter: BasePath,
+			file:     "/path/to/retry/retry.go",
+			function: "github.com/kamiaka/aerrors/retry.Do",
+			want:     "retry.go",
+		},
+		{
+			rewriter: PackagePath,
+			file:     "/path/to/retry/retry.go",
+			function: "github.com/kamiaka/aerrors/retry.(*Policy).Backoff",
+			want:     "github.com/kamiaka/aerrors/retry/retry.go",
+		},
+		{
+			rewriter: ModuleRelativePath,
+			file:     "/path/to/retry/retry.go",
+			function: "github.com/kamiaka/aerrors/retry.Do.func1",
+			want:     "retry/retry.go",
+		},
+		{
+			rewriter: ModuleRelativePath,
+			file:     "/path/to/aerrors/error.go",
+			function: "github.com/kamiaka/aerrors.New",
+			want:     "error.go",
+		},
+		{
+			rewriter: ModuleRelativePath,
+			file:     "github.com/kamiaka/aerrors/cmd/app/main.go",
+			function: "main.main",
+			want:     "cmd/app/main.go",
+		},
+		{
+			rewriter: ModuleRelativePath,
+			file:     path.Dir(thisFile()) + "/cmd/app/main.go",
+			function: "main.main",
+			want:     "cmd/app/main.go",
+		},
+		{
+			rewriter: ModuleRelativePath,
+			file:     "/nonexistent/app/main.go",
+			function: "main.main",
+			want:     "main.go",
+		},
+		{
+			rewriter: ModuleRelativePath,
+			file:     "/go/pkg/mod/example.com/other@v1.0.0/other.go",
+			function: "example.com/other.Func",
+			want:     "example.com/other/other.go",
+		},
+		{
+			rewriter: TrimPrefixPath("/go/src", "/home/user/project/"),
+			file:     "/home/user/project/pkg/file.go",
+			function: "example.com/project/pkg.Func",
+			want:     "pkg/file.go",
+		},
+		{
+			rewriter: TrimPrefixPath("/go/src"),
+			file:     "/home/user/project/pkg/file.go",
+			function: "example.com/project/pkg.Func",
+			want:     "/home/user/project/pkg/file.go",
+		},
+	}
+
+	for i, tc := range cases {
+		got := tc.rewriter(tc.file, tc.function)
+		if got != tc.want {
+			t.Errorf("#%d: rewrite(%#v, %#v) == %#v, want %#v", i, tc.file, tc.function, got, tc.want)
+		}
+	}
+}
+
+// thisFile returns the path of this file as it is recorded in callers.
+func thisFile() string {
+	_, file, _, _ := runtime.Caller(0)
+	return file
+}
+
+func TestErr_WithStack_callerPath(t *testing.T) {
+	err := New("oops", CallerPath(BasePath), CallerLine(false), Clock(testClock), GenerateID(testIDGenerator)).WithStackN(1, 0)
+
+	want := "aerrors.TestErr_WithStack_callerPath:callers_test.go"
+	if got := err.Values()[0].Value; got != want {
+		t.Errorf("stack == %#v, want %#v", got, want)
+	}
+
+	b, _ := json.Marshal(err)
+	wantJSON := `{"message":"oops","priority":"Error","callers":[{"function":"github.com/kamiaka/aerrors.TestErr_WithStack_callerPath","file":"callers_test.go"}],"time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"stack":"aerrors.TestErr_WithStack_callerPath:callers_test.go"}}`
+	if string(b) != wantJSON {
+		t.Errorf("json == %s, want %s", b, wantJSON)
+	}
+}
@@ -0,0 +1,60 @@
+package aerrors
+
+import "reflect"
+
+// MaxChainDepth limits the depth of walking parent and wrapped chains,
+// so that errors linked to themselves by WithError never hang formatting, serialization and helpers.
+var MaxChainDepth = 100
+
+// Markers rendered in place of errors when walking chains is stopped.
+const (
+	// CycleMarker is rendered in place of the error already rendered in the chain.
+	CycleMarker = "<cycle>"
+	// DepthMarker is rendered in place of errors deeper than MaxChainDepth.
+	DepthMarker = "<max depth>"
+)
+
+// visitor detects cycles and limits depth while walking chains.
+// It tracks errors on the current path, so errors shared by branches are not cycles.
+type visitor struct {
+	seen     map[error]bool
+	depth    int
+	maxDepth int
+}
+
+func newVisitor() *visitor {
+	return &visitor{seen: map[error]bool{}}
+}
+
+// visit reports whether `err` should be walked, and returns the marker if not.
+func (v *visitor) visit(err error) (marker string, ok bool) {
+	if v.depth >= MaxChainDepth || v.maxDepth > 0 && v.depth >= v.maxDepth {
+		return DepthMarker, false
+	}
+	if t := reflect.TypeOf(err); t != nil && t.Comparable() {
+		if v.seen[err] {
+			return CycleMarker, false
+		}
+		v.seen[err] = true
+	}
+	v.depth++
+	return "", true
+}
+
+// fork returns visitor for a branch.
+func (v *visitor) fork() *visitor {
+	seen := make(map[error]bool, len(v.seen))
+	for err := range v.seen {
+		seen[err] = true
+	}
+	return &visitor{seen: seen, depth: v.depth, maxDepth: v.maxDepth}
+}
+
+// parents returns parents from the nearest one, up to MaxChainDepth.
+func (e *Err) parents() []*Err {
+	var parents []*Err
+	for parent := e.parent; parent != nil && parent != e && len(parents) < MaxChainDepth; parent = parent.parent {
+		parents = append(parents, parent)
+	}
+	return parents
+}
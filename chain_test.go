@@ -0,0 +1,122 @@
+package aerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestChain_cycle(t *testing.T) {
+	conf := DefaultConfig.Clone().WithCallerDepth(0).WithClock(testClock).WithIDGenerator(testIDGenerator)
+
+	self := conf.Error("self")
+	self.WithError(self)
+
+	a := conf.Error("a")
+	b := conf.Error("b")
+	a.WithError(b)
+	b.WithError(a)
+
+	joined := conf.Error("joined")
+	joined.WithError(errors.Join(errors.New("other"), joined))
+
+	cases := []struct {
+		err        *Err
+		wantDetail string
+		wantJSON   string
+		wantGo     string
+		wantWalk   int
+	}{
+		{
+			err:        self,
+			wantDetail: "self:\n    priority: Error\n    callers: \n    time: 2001-02-03T04:05:06.000000007Z\n    error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV\n  - <cycle>",
+			wantJSON:   `{"message":"self","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","wrapped":{"message":"<cycle>"}}`,
+			wantGo:     `&aerrors.Err{Message: "self", Priority: aerrors.Error, Wrapped: <cycle>}`,
+			wantWalk:   1,
+		},
+		{
+			err:        a,
+			wantDetail: "a:\n    priority: Error\n    callers: \n    time: 2001-02-03T04:05:06.000000007Z\n    error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV\n  - b:\n    priority: Error\n    callers: \n    time: 2001-02-03T04:05:06.000000007Z\n    error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV\n  - <cycle>",
+			wantJSON:   `{"message":"a","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","wrapped":{"message":"b","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","wrapped":{"message":"<cycle>"}}}`,
+			wantGo:     `&aerrors.Err{Message: "a", Priority: aerrors.Error, Wrapped: &aerrors.Err{Message: "b", Priority: aerrors.Error, Wrapped: <cycle>}}`,
+			wantWalk:   2,
+		},
+		{
+			err:        joined,
+			wantDetail: "joined:\n    priority: Error\n    callers: \n    time: 2001-02-03T04:05:06.000000007Z\n    error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV\n    - other\n    - <cycle>",
+			wantJSON:   `{"message":"joined","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","wrapped":{"message":"other\njoined","errors":[{"message":"other"},{"message":"<cycle>"}]}}`,
+			wantGo:     `&aerrors.Err{Message: "joined", Priority: aerrors.Error, Wrapped: errors.Join(&errors.errorString{s:"other"}, <cycle>)}`,
+			wantWalk:   3,
+		},
+	}
+
+	for i, tc := range cases {
+		if got := fmt.Sprintf("%+v", tc.err); got != tc.wantDetail {
+			t.Errorf("#%d: %%+v == %#v, want %#v", i, got, tc.wantDetail)
+		}
+		if got := fmt.Sprintf("%v", tc.err); got != tc.err.Error() {
+			t.Errorf("#%d: %%v == %#v, want %#v", i, got, tc.err.Error())
+		}
+		b, err := json.Marshal(tc.err)
+		if err != nil {
+			t.Errorf("#%d: json.Marshal() returns error: %v", i, err)
+		}
+		if got := unescapeHTML(string(b)); got != tc.wantJSON {
+			t.Errorf("#%d: json == %s, want %s", i, got, tc.wantJSON)
+		}
+		if got := fmt.Sprintf("%#v", tc.err); got != tc.wantGo {
+			t.Errorf("#%d: %%#v == %s, want %s", i, got, tc.wantGo)
+		}
+		n := 0
+		Walk(tc.err, func(error) bool {
+			n++
+			return true
+		})
+		if n != tc.wantWalk {
+			t.Errorf("#%d: Walk visits %d errors, want %d", i, n, tc.wantWalk)
+		}
+		if e, ok := AsErr(tc.err); !ok || e != tc.err {
+			t.Errorf("#%d: AsErr() == %v, %v", i, e, ok)
+		}
+		if !tc.err.Is(tc.err) {
+			t.Errorf("#%d: Is(itself) == false", i)
+		}
+	}
+}
+
+func TestChain_maxDepth(t *testing.T) {
+	tmp := MaxChainDepth
+	MaxChainDepth = 3
+	defer func() { MaxChainDepth = tmp }()
+
+	var err error = errors.New("origin")
+	for i := 0; i < 5; i++ {
+		err = fmt.Errorf("wrap%d: %w", i, err)
+	}
+	e := New("top", CallerDepth(0), Clock(testClock), GenerateID(testIDGenerator)).WithError(err)
+
+	want := "top:\n    priority: Error\n    callers: \n    time: 2001-02-03T04:05:06.000000007Z\n    error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV\n  - wrap4: wrap3: wrap2: wrap1: wrap0: origin"
+	if got := fmt.Sprintf("%+v", e); got != want {
+		t.Errorf("%%+v == %#v, want %#v", got, want)
+	}
+
+	n := 0
+	Walk(e, func(error) bool {
+		n++
+		return true
+	})
+	if n != 3 {
+		t.Errorf("Walk visits %d errors, want 3", n)
+	}
+
+	b, _ := json.Marshal(e)
+	if !strings.Contains(unescapeHTML(string(b)), `"wrapped":{"message":"<max depth>"}`) {
+		t.Errorf("json: %s", b)
+	}
+}
+
+func unescapeHTML(s string) string {
+	return strings.NewReplacer(`\u003c`, "<", `\u003e`, ">").Replace(s)
+}
@@ -1,11 +1,27 @@
 package aerrors
 
+import "time"
+
 // Config for create *Err
 type Config struct {
-	priority    ErrorPriority
-	formatError ErrorFormatter
-	callerDepth int
-	callerSkip  int
+	priority     ErrorPriority
+	formatError  ErrorFormatter
+	callerDepth  int
+	callerSkip   int
+	publicMsg    string
+	msgKey       string
+	catalog      MessageCatalog
+	hooks        *hookSet
+	fingerprint  FingerprintComponent
+	captureArgs  bool
+	callerPath   PathRewriter
+	callerLine   bool
+	valuesPolicy ValueInheritancePolicy
+	namespace    string
+	bytesFormat  BytesFormat
+	limits       Limits
+	clock        func() time.Time
+	idGenerator  IDGenerator
 }
 
 // DefaultConfig for create *Err.
@@ -14,6 +30,11 @@ var DefaultConfig = &Config{
 	formatError: NewFormatter("\n", ": "),
 	callerDepth: 1,
 	callerSkip:  0,
+	callerLine:  true,
+	hooks:       &hookSet{},
+	fingerprint: DefaultFingerprint,
+	clock:       time.Now,
+	idGenerator: NewID,
 }
 
 // Priority represents error priority.
@@ -61,9 +82,188 @@ func (c *Config) WithCallerSkip(n int) *Config {
 	return c
 }
 
+// PublicMessage returns the message that is safe to show to end users.
+func (c *Config) PublicMessage() string {
+	return c.publicMsg
+}
+
+// WithPublicMessage sets the message that is safe to show to end users and return receiver.
+func (c *Config) WithPublicMessage(msg string) *Config {
+	c.publicMsg = msg
+	return c
+}
+
+// MessageKey returns the key of localized message.
+func (c *Config) MessageKey() string {
+	return c.msgKey
+}
+
+// WithMessageKey sets the key of localized message and return receiver.
+func (c *Config) WithMessageKey(key string) *Config {
+	c.msgKey = key
+	return c
+}
+
+// Catalog returns catalog for localized messages.
+// If it is nil, DefaultCatalog is used.
+func (c *Config) Catalog() MessageCatalog {
+	return c.catalog
+}
+
+// WithCatalog sets catalog for localized messages and return receiver.
+func (c *Config) WithCatalog(catalog MessageCatalog) *Config {
+	c.catalog = catalog
+	return c
+}
+
+// Hooks returns hooks called when new error is created, including hooks of configs it is cloned from.
+func (c *Config) Hooks() []Hook {
+	return c.hooks.all()
+}
+
+// WithHook appends hooks called when new error is created and return receiver.
+//
+// Hooks also apply to clones of the config and errors created by the config before,
+// so hooks can be registered to DefaultConfig after package-level sentinel errors are created.
+// Hooks appended to the clone do not apply to the original.
+func (c *Config) WithHook(hooks ...Hook) *Config {
+	if c.hooks == nil {
+		c.hooks = &hookSet{}
+	}
+	c.hooks.add(hooks)
+	return c
+}
+
+// Fingerprint returns components of fingerprint.
+func (c *Config) Fingerprint() FingerprintComponent {
+	return c.fingerprint
+}
+
+// WithFingerprint sets components of fingerprint and return receiver.
+func (c *Config) WithFingerprint(components FingerprintComponent) *Config {
+	c.fingerprint = components
+	return c
+}
+
+// CallerPath returns PathRewriter for callers and stack traces.
+// If it is nil, paths are not rewritten.
+func (c *Config) CallerPath() PathRewriter {
+	return c.callerPath
+}
+
+// WithCallerPath sets PathRewriter for callers and stack traces and return receiver.
+func (c *Config) WithCallerPath(r PathRewriter) *Config {
+	c.callerPath = r
+	return c
+}
+
+// CallerLine returns whether line numbers of callers and stack traces are rendered.
+func (c *Config) CallerLine() bool {
+	return c.callerLine
+}
+
+// WithCallerLine sets whether line numbers of callers and stack traces are rendered and return receiver.
+func (c *Config) WithCallerLine(b bool) *Config {
+	c.callerLine = b
+	return c
+}
+
+func (c *Config) callerFormat() callerFormat {
+	return callerFormat{path: c.callerPath, line: c.callerLine}
+}
+
+// ValueInheritance returns the policy of values inherited by children.
+func (c *Config) ValueInheritance() ValueInheritancePolicy {
+	return c.valuesPolicy
+}
+
+// WithValueInheritance sets the policy of values inherited by children and return receiver.
+func (c *Config) WithValueInheritance(p ValueInheritancePolicy) *Config {
+	c.valuesPolicy = p
+	return c
+}
+
+// Namespace returns the namespace prefixed to labels of values added to the error.
+func (c *Config) Namespace() string {
+	return c.namespace
+}
+
+// WithNamespace sets the namespace prefixed to labels of values added to the error and return receiver.
+// Labels are joined by GroupSeparator, e.g. "db.id".
+func (c *Config) WithNamespace(ns string) *Config {
+	c.namespace = ns
+	return c
+}
+
+// BytesFormat returns the format rendering bytes Values added to the error.
+func (c *Config) BytesFormat() BytesFormat {
+	return c.bytesFormat
+}
+
+// WithBytesFormat sets the format rendering bytes Values added to the error and return receiver.
+func (c *Config) WithBytesFormat(f BytesFormat) *Config {
+	c.bytesFormat = f
+	return c
+}
+
+// Limits returns the size budget of the error output.
+func (c *Config) Limits() Limits {
+	return c.limits
+}
+
+// WithLimits sets the size budget of the error output and return receiver.
+func (c *Config) WithLimits(l Limits) *Config {
+	c.limits = l
+	return c
+}
+
+// Clock returns the func returns the time when the error is created.
+func (c *Config) Clock() func() time.Time {
+	return c.clock
+}
+
+// WithClock sets the func returns the time when the error is created and return receiver.
+// If it is nil, time.Now is used.
+func (c *Config) WithClock(now func() time.Time) *Config {
+	c.clock = now
+	return c
+}
+
+// IDGenerator returns the generator of the error ID.
+func (c *Config) IDGenerator() IDGenerator {
+	return c.idGenerator
+}
+
+// WithIDGenerator sets the generator of the error ID and return receiver.
+// If it is nil, the error has no ID.
+func (c *Config) WithIDGenerator(g IDGenerator) *Config {
+	c.idGenerator = g
+	return c
+}
+
+// CaptureArgs returns whether arguments of Errorf are captured as Values.
+func (c *Config) CaptureArgs() bool {
+	return c.captureArgs
+}
+
+// WithCaptureArgs sets whether arguments of Errorf are captured as Values labeled by ArgLabel and return receiver.
+func (c *Config) WithCaptureArgs(b bool) *Config {
+	c.captureArgs = b
+	return c
+}
+
+// child returns *Config for new child of the error created by the config.
+func (c *Config) child() *Config {
+	child := c.Clone()
+	child.callerSkip = 0
+	child.msgKey = ""
+	return child
+}
+
 // Clone *Config.
 func (c *Config) Clone() *Config {
 	copy := *c
+	copy.hooks = c.hooks.child()
 	return &copy
 }
 
@@ -74,7 +274,8 @@ func (c *Config) Error(msg string, opts ...Option) *Err {
 
 // Errorf formats according to a format specifier and returns the string as a value that satisfies error.
 //
-// If the format specifier has suffix `: %w` verb with an error operand, the returned error will implement an Unwrap method returning the operand.
+// If the format specifier includes a `%w` verb with an error operand, the returned error will implement an Unwrap method returning the operand.
+// If there are multiple `%w` verbs, Unwrap returns an error implementing `Unwrap() []error` that returns the operands in argument order.
 func (c *Config) Errorf(format string, args ...interface{}) *Err {
 	return errorf(c, format, args...)
 }
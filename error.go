@@ -2,23 +2,38 @@ package aerrors
 
 import (
 	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/kamiaka/aerrors/internal/stack"
-	"golang.org/x/xerrors"
 )
 
 // Err is aerror's error. It implements interface `error`.
 type Err struct {
 	msg          string
+	template     string
+	args         []interface{}
 	parent       *Err
 	wrappedError error
 	callers      *stack.Frames
 	priority     ErrorPriority
 	formatError  ErrorFormatter
 	values       []*Value
+	publicMsg    string
+	msgKey       string
+	catalog      MessageCatalog
+	fingerprint  FingerprintComponent
+	callerFormat callerFormat
+	namespace    string
+	bytesFormat  BytesFormat
+	limits       Limits
+	time         time.Time
+	id           string
 	childConf    *Config
 }
 
@@ -34,45 +49,62 @@ func newErr(conf *Config, msg string, opts ...Option) *Err {
 		conf = opt(conf)
 	}
 
-	return &Err{
-		msg:         msg,
-		callers:     stack.Callers(conf.callerDepth, conf.callerSkip+2),
-		priority:    conf.priority,
-		formatError: conf.formatError,
-		childConf:   conf.WithCallerSkip(0),
+	e := &Err{
+		msg:          msg,
+		template:     msg,
+		callers:      stack.Callers(conf.callerDepth, conf.callerSkip+2),
+		priority:     conf.priority,
+		formatError:  conf.formatError,
+		publicMsg:    conf.publicMsg,
+		msgKey:       conf.msgKey,
+		catalog:      conf.catalog,
+		fingerprint:  conf.fingerprint,
+		callerFormat: conf.callerFormat(),
+		namespace:    conf.namespace,
+		bytesFormat:  conf.bytesFormat,
+		limits:       conf.limits,
+		childConf:    conf.child(),
 	}
+	e.stamp(conf)
+	e.runHooks()
+	return e
 }
 
 // Errorf formats according to a format specifier and returns the string as a value that satisfies error.
 //
-// If the format specifier has suffix `: %w` verb with an error operand, the returned error will implement an Unwrap method returning the operand.
+// If the format specifier includes a `%w` verb with an error operand, the returned error will implement an Unwrap method returning the operand.
+// If there are multiple `%w` verbs, Unwrap returns an error implementing `Unwrap() []error` that returns the operands in argument order.
 func Errorf(format string, args ...interface{}) *Err {
 	return errorf(DefaultConfig, format, args...)
 }
 
 func errorf(conf *Config, format string, args ...interface{}) *Err {
 	conf = conf.Clone()
+	template := format
 	format, wrappedError := wrappedFormat(format, args)
 
-	return &Err{
+	e := &Err{
 		msg:          fmt.Sprintf(format, args...),
+		template:     template,
+		args:         copyArgs(args),
 		callers:      stack.Callers(conf.callerDepth, conf.callerSkip+2),
 		priority:     conf.priority,
 		formatError:  conf.formatError,
+		publicMsg:    conf.publicMsg,
+		msgKey:       conf.msgKey,
+		catalog:      conf.catalog,
+		fingerprint:  conf.fingerprint,
+		callerFormat: conf.callerFormat(),
+		namespace:    conf.namespace,
+		bytesFormat:  conf.bytesFormat,
+		limits:       conf.limits,
 		wrappedError: wrappedError,
-		childConf:    conf.WithCallerSkip(0),
+		childConf:    conf.child(),
 	}
-}
-
-func wrappedFormat(format string, args []interface{}) (newFormat string, wrappedError error) {
-	isWrapped := strings.HasSuffix(format, ": %w")
-	if isWrapped && len(args) > 0 {
-		format = string([]byte(format)[:len(format)-4]) + ": %v"
-		if e, ok := args[len(args)-1].(error); ok {
-			wrappedError = e
-		}
-	}
-	return format, wrappedError
+	e.stamp(conf)
+	e.captureArgs(conf)
+	e.runHooks()
+	return e
 }
 
 // Error implements interface `error`.
@@ -94,11 +126,25 @@ func (e *Err) newChild(msg string, opts ...Option) *Err {
 	}
 
 	child.msg = msg
+	child.template = msg
+	child.args = nil
+	child.values = conf.valuesPolicy.inherit(e.values)
 	child.callers = stack.Callers(conf.callerDepth, conf.callerSkip+2)
 	child.parent = e
 	child.priority = conf.priority
 	child.formatError = conf.formatError
-	child.childConf = conf.WithCallerSkip(0)
+	if conf.publicMsg != "" {
+		child.publicMsg = conf.publicMsg
+	}
+	child.msgKey = conf.msgKey
+	child.catalog = conf.catalog
+	child.fingerprint = conf.fingerprint
+	child.callerFormat = conf.callerFormat()
+	child.namespace = conf.namespace
+	child.bytesFormat = conf.bytesFormat
+	child.limits = conf.limits
+	child.childConf = conf.child()
+	child.stamp(conf)
 
 	return child
 }
@@ -107,32 +153,86 @@ func (e *Err) newChild(msg string, opts ...Option) *Err {
 func (e *Err) New(msg string, options ...Option) *Err {
 	child := e.newChild(msg, options...)
 	child.wrappedError = nil
+	child.runHooks()
 	return child
 }
 
 // Errorf returns new child *Err with message.
+//
+// `%w` verbs are handled in the same way as Errorf.
 func (e *Err) Errorf(format string, args ...interface{}) *Err {
+	template := format
 	format, wrappedError := wrappedFormat(format, args)
 
 	child := e.newChild(fmt.Sprintf(format, args...))
+	child.template = template
+	child.args = copyArgs(args)
+	child.captureArgs(child.childConf)
 	child.wrappedError = wrappedError
+	child.runHooks()
 
 	return child
 }
 
 // Wrap specified error `err`.
 func (e *Err) Wrap(err error, opts ...Option) *Err {
-	child := e.newChild(e.Error(), opts...)
+	child := e.newChild(e.Error(), append([]Option{MessageKey(e.msgKey)}, opts...)...)
+	child.template = e.template
+	child.args = e.args
 	child.wrappedError = err
+	child.runHooks()
 	return child
 }
 
+// Template returns the message template.
+// It is the format specifier for errors created by Errorf, otherwise the message.
+func (e *Err) Template() string {
+	return e.template
+}
+
+// Args returns a copy of the arguments of Errorf.
+func (e *Err) Args() []interface{} {
+	return copyArgs(e.args)
+}
+
+// copyArgs returns a copy of `args`, so the caller's slice is not shared.
+func copyArgs(args []interface{}) []interface{} {
+	if args == nil {
+		return nil
+	}
+	return append([]interface{}(nil), args...)
+}
+
+// captureArgs adds arguments of Errorf as values, so Namespace and BytesFormat of the error apply to them.
+// []byte arguments are captured as Bytes.
+func (e *Err) captureArgs(conf *Config) {
+	if !conf.captureArgs {
+		return
+	}
+	e.values = e.values[:len(e.values):len(e.values)]
+	for i, arg := range e.args {
+		if b, ok := arg.([]byte); ok {
+			e.addValues(Bytes(ArgLabel(i), b))
+			continue
+		}
+		e.addValues(Stringf(ArgLabel(i), "%v", arg))
+	}
+}
+
+// ArgLabel returns label of the Value captured from the i-th argument of Errorf, e.g., "arg0".
+func ArgLabel(i int) string {
+	return "arg" + strconv.Itoa(i)
+}
+
 // Unwrap error.
 func (e *Err) Unwrap() error {
 	return e.wrappedError
 }
 
 // WithError sets wrapped error and returns receiver.
+//
+// Formatting, serialization and helpers of this package stop at an error linked in a cycle,
+// but errors.Is and errors.As of the standard library do not, so do not wrap the error itself or its ancestors.
 func (e *Err) WithError(err error) *Err {
 	e.wrappedError = err
 	return e
@@ -140,23 +240,59 @@ func (e *Err) WithError(err error) *Err {
 
 // Is reports whether the error `err` is `e`
 func (e *Err) Is(err error) bool {
-	return e == err || (e.parent != nil && e.parent.Is(err))
+	if e == err {
+		return true
+	}
+	for _, parent := range e.parents() {
+		if parent == err {
+			return true
+		}
+	}
+	return false
 }
 
 // Format implements interface `fmt.Formatter`
+//
+// It supports `%v`, `%s`, `%q`, `%x`, `%X` and `%#v`.
+// `%+v` prints the detail of the error and its wrapped errors.
 func (e *Err) Format(s fmt.State, verb rune) {
-	xerrors.FormatError(e, s, verb)
+	formatError(e, s, verb)
 }
 
-// FormatError implements interface `xerrors.Formatter`
-func (e *Err) FormatError(p xerrors.Printer) (next error) {
+// FormatError prints the error to the Printer and returns the next error to print.
+//
+// If the wrapped error has multiple errors, i.e. implements `Unwrap() []error`,
+// they are printed as indented branches in detail.
+func (e *Err) FormatError(p Printer) (next error) {
 	next = e.formatError(p, e)
 	if p.Detail() {
+		if multi, ok := e.wrappedError.(interface{ Unwrap() []error }); ok {
+			printBranches(p, multi.Unwrap())
+			return nil
+		}
 		return e.wrappedError
 	}
 	return next
 }
 
+func printBranches(p Printer, errs []error) {
+	v := newVisitor()
+	if pp, ok := p.(*printer); ok && pp.visited != nil {
+		v = pp.visited
+	}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		p.Print("\n- ", strings.ReplaceAll(sprintDetail(err, v.fork()), "\n", "\n  "))
+	}
+}
+
+// Limits returns the size budget of the error output.
+func (e *Err) Limits() Limits {
+	return e.limits
+}
+
 // Parent return parent *Err.
 func (e *Err) Parent() *Err {
 	return e.parent
@@ -164,18 +300,42 @@ func (e *Err) Parent() *Err {
 
 // WithValue sets the `values` and returns receiver.
 func (e *Err) WithValue(values ...*Value) *Err {
-	e.values = append(e.values, values...)
-	return e
+	return e.addValues(values...)
 }
 
-// Values set by `WithValue` method.
+// Values set by `WithValue` method, including values inherited from the parent by the ValueInheritancePolicy.
 func (e *Err) Values() []*Value {
 	return e.values
 }
 
+// AllValues returns values merged across the parent chain.
+//
+// Values are ordered from the root parent's, and a value of the child overrides the parent's value with the same label.
+// The overriding value takes the position of the first value with the label.
+func (e *Err) AllValues() []*Value {
+	parents := e.parents()
+	var all []*Value
+	index := map[string]int{}
+	for i := len(parents); i >= 0; i-- {
+		err := e
+		if i > 0 {
+			err = parents[i-1]
+		}
+		for _, v := range err.values {
+			if j, ok := index[v.Label]; ok {
+				all[j] = v
+				continue
+			}
+			index[v.Label] = len(all)
+			all = append(all, v)
+		}
+	}
+	return all
+}
+
 // Callers returns error callers.
 func (e *Err) Callers() *runtime.Frames {
-	return e.callers.Frames
+	return e.callers.Frames()
 }
 
 // Priority returns error priority.
@@ -189,6 +349,42 @@ func (e *Err) WithPriority(p ErrorPriority) *Err {
 	return e
 }
 
+// PublicMessage returns the message that is safe to show to end users.
+//
+// If the error has no public message, the nearest parent's one is returned.
+// If no parents have it, the message for the priority in PublicMessages is returned.
+func (e *Err) PublicMessage() string {
+	if e.publicMsg != "" {
+		return e.publicMsg
+	}
+	for _, parent := range e.parents() {
+		if parent.publicMsg != "" {
+			return parent.publicMsg
+		}
+	}
+	if msg, ok := PublicMessages[e.priority]; ok {
+		return msg
+	}
+	return DefaultPublicMessage
+}
+
+// WithPublicMessage sets the message that is safe to show to end users and returns receiver.
+func (e *Err) WithPublicMessage(msg string) *Err {
+	e.publicMsg = msg
+	return e
+}
+
+// MessageKey returns the key of localized message.
+func (e *Err) MessageKey() string {
+	return e.msgKey
+}
+
+// WithMessageKey sets the key of localized message and returns receiver.
+func (e *Err) WithMessageKey(key string) *Err {
+	e.msgKey = key
+	return e
+}
+
 // Config returns aerror's config.
 //
 // Deprecated: Use *Err.ChildConfig.
@@ -209,138 +405,156 @@ func (e *Err) WithChildConfig(c *Config) *Err {
 
 // WithString appends string Value and returns receiver.
 func (e *Err) WithString(l, v string) *Err {
-	e.values = append(e.values, String(l, v))
-	return e
+	return e.addValues(String(l, v))
 }
 
 // WithStringer appends stringer Value and returns receiver.
 func (e *Err) WithStringer(l string, v interface{ String() string }) *Err {
-	e.values = append(e.values, Stringer(l, v))
-	return e
+	return e.addValues(Stringer(l, v))
 }
 
 // WithStringf appends formatted string Value and returns receiver.
 func (e *Err) WithStringf(l string, format string, args ...interface{}) *Err {
-	e.values = append(e.values, Stringf(l, format, args...))
-	return e
+	return e.addValues(Stringf(l, format, args...))
 }
 
 // WithBool appends bool Value and returns receiver.
 func (e *Err) WithBool(l string, v bool) *Err {
-	e.values = append(e.values, Bool(l, v))
-	return e
+	return e.addValues(Bool(l, v))
 }
 
 // WithBytes appends bytes Value and returns receiver.
 func (e *Err) WithBytes(l string, v []byte) *Err {
-	e.values = append(e.values, Bytes(l, v))
-	return e
+	return e.addValues(Bytes(l, v))
 }
 
 // WithByte appends byte Value and returns receiver.
 func (e *Err) WithByte(l string, v byte) *Err {
-	e.values = append(e.values, Byte(l, v))
-	return e
+	return e.addValues(Byte(l, v))
 }
 
 // WithRune appends rune Value and returns receiver.
 func (e *Err) WithRune(l string, v rune) *Err {
-	e.values = append(e.values, Rune(l, v))
-	return e
+	return e.addValues(Rune(l, v))
 }
 
 // WithInt appends int Value and returns receiver.
 func (e *Err) WithInt(l string, v int) *Err {
-	e.values = append(e.values, Int(l, v))
-	return e
+	return e.addValues(Int(l, v))
 }
 
 // WithInt8 appends Int8 Value and returns receiver.
 func (e *Err) WithInt8(l string, v int8) *Err {
-	e.values = append(e.values, Int8(l, v))
-	return e
+	return e.addValues(Int8(l, v))
 }
 
 // WithInt16 appends Int16 Value and returns receiver.
 func (e *Err) WithInt16(l string, v int16) *Err {
-	e.values = append(e.values, Int16(l, v))
-	return e
+	return e.addValues(Int16(l, v))
 }
 
 // WithInt32 appends Int32 Value and returns receiver.
 func (e *Err) WithInt32(l string, v int32) *Err {
-	e.values = append(e.values, Int32(l, v))
-	return e
+	return e.addValues(Int32(l, v))
 }
 
 // WithInt64 appends Int64 Value and returns receiver.
 func (e *Err) WithInt64(l string, v int64) *Err {
-	e.values = append(e.values, Int64(l, v))
-	return e
+	return e.addValues(Int64(l, v))
 }
 
 // WithUint appends Uint Value and returns receiver.
 func (e *Err) WithUint(l string, v uint) *Err {
-	e.values = append(e.values, Uint(l, v))
-	return e
+	return e.addValues(Uint(l, v))
 }
 
 // WithUint8 appends Uint8 Value and returns receiver.
 func (e *Err) WithUint8(l string, v uint8) *Err {
-	e.values = append(e.values, Uint8(l, v))
-	return e
+	return e.addValues(Uint8(l, v))
 }
 
 // WithUint16 appends Uint16 Value and returns receiver.
 func (e *Err) WithUint16(l string, v uint16) *Err {
-	e.values = append(e.values, Uint16(l, v))
-	return e
+	return e.addValues(Uint16(l, v))
 }
 
 // WithUint32 appends Uint32 Value and returns receiver.
 func (e *Err) WithUint32(l string, v uint32) *Err {
-	e.values = append(e.values, Uint32(l, v))
-	return e
+	return e.addValues(Uint32(l, v))
 }
 
 // WithUint64 appends Uint64 Value and returns receiver.
 func (e *Err) WithUint64(l string, v uint64) *Err {
-	e.values = append(e.values, Uint64(l, v))
-	return e
+	return e.addValues(Uint64(l, v))
 }
 
 // WithFloat32 appends Float32 Value and returns receiver.
 func (e *Err) WithFloat32(l string, v float32) *Err {
-	e.values = append(e.values, Float32(l, v))
-	return e
+	return e.addValues(Float32(l, v))
 }
 
 // WithFloat64 appends Float64 Value and returns receiver.
 func (e *Err) WithFloat64(l string, v float64) *Err {
-	e.values = append(e.values, Float64(l, v))
-	return e
+	return e.addValues(Float64(l, v))
 }
 
 // WithTime appends Time Value and returns receiver.
 func (e *Err) WithTime(l string, v time.Time) *Err {
-	e.values = append(e.values, Time(l, v))
-	return e
+	return e.addValues(Time(l, v))
 }
 
 // WithUTCTime appends UTCTime Value and returns receiver.
 func (e *Err) WithUTCTime(l string, v time.Time) *Err {
-	e.values = append(e.values, UTCTime(l, v))
-	return e
+	return e.addValues(UTCTime(l, v))
+}
+
+// WithDuration appends duration Value and returns receiver.
+func (e *Err) WithDuration(l string, v time.Duration) *Err {
+	return e.addValues(Duration(l, v))
+}
+
+// WithErrorValue appends error Value and returns receiver.
+// Unlike WithError, the error is not wrapped.
+func (e *Err) WithErrorValue(l string, v error) *Err {
+	return e.addValues(ErrorValue(l, v))
+}
+
+// WithIP appends IP address Value and returns receiver.
+func (e *Err) WithIP(l string, v net.IP) *Err {
+	return e.addValues(IP(l, v))
+}
+
+// WithAddr appends IP address Value and returns receiver.
+func (e *Err) WithAddr(l string, v netip.Addr) *Err {
+	return e.addValues(Addr(l, v))
+}
+
+// WithURL appends URL Value with the password stripped and returns receiver.
+func (e *Err) WithURL(l string, v *url.URL) *Err {
+	return e.addValues(URL(l, v))
+}
+
+// WithStrings appends string slice Value and returns receiver.
+func (e *Err) WithStrings(l string, v []string) *Err {
+	return e.addValues(Strings(l, v))
+}
+
+// WithStringMap appends string map Value and returns receiver.
+func (e *Err) WithStringMap(l string, v map[string]string) *Err {
+	return e.addValues(StringMap(l, v))
 }
 
 // WithStack appends Stack Value and returns receiver.
+// The stack trace is rendered according to CallerPath and CallerLine of the config.
 func (e *Err) WithStack(skip int) *Err {
-	e.values = append(e.values, Stack(skip+1))
-	return e
+	return e.WithStackN(DefaultStackDepth, skip+1)
 }
 
 // WithStackN appends Stack Value and returns receiver.
+// The stack trace is rendered according to CallerPath and CallerLine of the config.
 func (e *Err) WithStackN(depth, skip int) *Err {
-	e.values = append(e.values, StackN(depth, skip+1))
-	return e
+	return e.addValues(&Value{
+		Label: "stack",
+		Value: e.callerFormat.format(stack.Callers(depth, skip+1)),
+	})
 }
@@ -3,6 +3,7 @@ package aerrors
 import (
 	"errors"
 	"fmt"
+	"testing"
 )
 
 func ExampleNew() {
@@ -14,26 +15,33 @@ func ExampleNew() {
 }
 
 func ExampleNew_verbose() {
-	err := New("new error")
+	err := New("new error", Clock(testClock), GenerateID(testIDGenerator))
 
 	fmt.Printf("%+v", err)
 	// Output:
 	// new error:
 	//     priority: Error
-	//     callers: aerrors.ExampleNew_verbose:github.com/kamiaka/aerrors/error_test.go:17
+	//     callers: aerrors.ExampleNew_verbose:github.com/kamiaka/aerrors/error_test.go:18
+	//     time: 2001-02-03T04:05:06.000000007Z
+	//     error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV
 }
 
 func ExampleNew_childVerbose() {
-	err := Errorf("new error: %w", New("oops"))
+	conf := DefaultConfig.Clone().WithClock(testClock).WithIDGenerator(testIDGenerator)
+	err := conf.Errorf("new error: %w", conf.Error("oops"))
 
 	fmt.Printf("%+v", err)
 	// Output:
 	// new error: oops:
 	//     priority: Error
-	//     callers: aerrors.ExampleNew_childVerbose:github.com/kamiaka/aerrors/error_test.go:27
+	//     callers: aerrors.ExampleNew_childVerbose:github.com/kamiaka/aerrors/error_test.go:31
+	//     time: 2001-02-03T04:05:06.000000007Z
+	//     error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV
 	//   - oops:
 	//     priority: Error
-	//     callers: aerrors.ExampleNew_childVerbose:github.com/kamiaka/aerrors/error_test.go:27
+	//     callers: aerrors.ExampleNew_childVerbose:github.com/kamiaka/aerrors/error_test.go:31
+	//     time: 2001-02-03T04:05:06.000000007Z
+	//     error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV
 }
 
 func ExampleNew_with_options() {
@@ -169,3 +177,76 @@ func ExampleErr_Config() {
 	// Output:
 	// 1
 }
+
+func ExampleErr_PublicMessage() {
+	appError := New("app error", PublicMessage("Something went wrong."))
+	err := appError.Errorf("query failed: %w", errors.New("connection refused"))
+	warning := New("invalid state", Priority(Warning))
+
+	fmt.Println(err)
+	fmt.Println(err.PublicMessage())
+	fmt.Println(warning.PublicMessage())
+	// Output:
+	// query failed: connection refused
+	// Something went wrong.
+	// The request could not be completed.
+}
+
+func ExampleErr_Template() {
+	err := Errorf("user %d is not found: %w", 42, errors.New("oops"))
+
+	fmt.Println(err)
+	fmt.Println(err.Template())
+	fmt.Println(err.Args()[0])
+	// Output:
+	// user 42 is not found: oops
+	// user %d is not found: %w
+	// 42
+}
+
+func ExampleCaptureArgs() {
+	appError := New("app error", CaptureArgs(true))
+	err := appError.Errorf("user %d is not found", 42)
+
+	for _, v := range err.Values() {
+		fmt.Println(v)
+	}
+	// Output:
+	// arg0: 42
+}
+
+func TestErr_captureArgs(t *testing.T) {
+	appError := New("app error", CaptureArgs(true), Namespace("ns"), FormatBytes(BytesFormat{Mode: BytesBase64}))
+	err := appError.Errorf("user %d sent %s", 42, []byte("foo"))
+
+	var got []string
+	for _, v := range err.Values() {
+		got = append(got, v.String())
+	}
+	if want := fmt.Sprint([]string{"ns.arg0: 42", "ns.arg1: Zm9v"}); fmt.Sprint(got) != want {
+		t.Errorf("values = %v, want %v", got, want)
+	}
+}
+
+func TestErr_Args(t *testing.T) {
+	args := []interface{}{42}
+	err := Errorf("user %d is not found", args...)
+
+	err.Args()[0] = 0
+	args[0] = 0
+	if got := err.Args(); len(got) != 1 || got[0] != 42 {
+		t.Errorf("Args() = %v, want [42]", got)
+	}
+}
+
+func ExampleErrorf_with_multiple_wrapped_errors() {
+	notFound := errors.New("not found")
+	denied := errors.New("permission denied")
+	err := Errorf("%w while reading, then %w", notFound, denied)
+
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, notFound), errors.Is(err, denied))
+	// Output:
+	// not found while reading, then permission denied
+	// true true
+}
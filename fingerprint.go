@@ -0,0 +1,60 @@
+package aerrors
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strconv"
+)
+
+// FingerprintComponent is set of components of fingerprint.
+type FingerprintComponent int
+
+// Fingerprint components.
+const (
+	// FingerprintLineage is the message templates of the parents.
+	FingerprintLineage FingerprintComponent = 1 << iota
+	// FingerprintTemplate is the message template, that is the format of Errorf.
+	FingerprintTemplate
+	// FingerprintCallers is the function names of the callers.
+	FingerprintCallers
+	// FingerprintPriority is the priority.
+	FingerprintPriority
+)
+
+// DefaultFingerprint is the components of DefaultConfig.
+const DefaultFingerprint = FingerprintLineage | FingerprintTemplate | FingerprintCallers
+
+// Fingerprint returns the key for grouping the same logical failures.
+//
+// It is computed from the components configured by Config.
+// It does not depend on the formatted arguments of Errorf nor the line numbers of callers,
+// so it is stable across deploys.
+func (e *Err) Fingerprint() string {
+	h := sha256.New()
+	components := e.fingerprint
+	if components&FingerprintLineage != 0 {
+		for _, parent := range e.parents() {
+			writeFingerprint(h, "parent", parent.template)
+		}
+	}
+	if components&FingerprintTemplate != 0 {
+		writeFingerprint(h, "template", e.template)
+	}
+	if components&FingerprintCallers != 0 {
+		for _, frame := range e.callers.List() {
+			writeFingerprint(h, "caller", frame.Function)
+		}
+	}
+	if components&FingerprintPriority != 0 {
+		writeFingerprint(h, "priority", strconv.Itoa(int(e.priority)))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+func writeFingerprint(w io.Writer, kind, s string) {
+	io.WriteString(w, kind)
+	io.WriteString(w, strconv.Itoa(len(s)))
+	io.WriteString(w, ":")
+	io.WriteString(w, s)
+}
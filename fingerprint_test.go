@@ -0,0 +1,71 @@
+package aerrors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestErr_Fingerprint(t *testing.T) {
+	appError := New("app error")
+	otherError := New("other error")
+	newNotFound := func(id int) *Err {
+		return appError.Errorf("user %d is not found", id)
+	}
+
+	cases := []struct {
+		a, b *Err
+		same bool
+	}{
+		{
+			a:    newNotFound(1),
+			b:    newNotFound(2),
+			same: true,
+		},
+		{
+			a:    newNotFound(1),
+			b:    newNotFound(1).WithPriority(Info),
+			same: true,
+		},
+		{
+			a:    appError.Errorf("user %d is not found", 1),
+			b:    otherError.Errorf("user %d is not found", 1),
+			same: false,
+		},
+		{
+			a:    newNotFound(1),
+			b:    appError.Errorf("user %d is not found", 1),
+			same: false,
+		},
+		{
+			a:    New("oops", Fingerprint(FingerprintTemplate)),
+			b:    func() *Err { return New("oops", Fingerprint(FingerprintTemplate)) }(),
+			same: true,
+		},
+		{
+			a:    New("oops", Fingerprint(FingerprintTemplate|FingerprintPriority)),
+			b:    New("oops", Fingerprint(FingerprintTemplate|FingerprintPriority), Priority(Info)),
+			same: false,
+		},
+	}
+
+	for i, tc := range cases {
+		a, b := tc.a.Fingerprint(), tc.b.Fingerprint()
+		if (a == b) != tc.same {
+			t.Errorf("#%d: fingerprints %s and %s, want same: %v", i, a, b, tc.same)
+		}
+		if len(a) != 32 {
+			t.Errorf("#%d: len(Fingerprint()) == %d, want 32", i, len(a))
+		}
+	}
+}
+
+func ExampleErr_Fingerprint() {
+	appError := New("app error", Fingerprint(FingerprintLineage|FingerprintTemplate))
+
+	a := appError.Errorf("user %d is not found", 1)
+	b := appError.Errorf("user %d is not found", 2)
+
+	fmt.Println(a.Fingerprint() == b.Fingerprint())
+	// Output:
+	// true
+}
@@ -1,29 +1,30 @@
 package aerrors
 
-import (
-	"golang.org/x/xerrors"
-)
+import "time"
 
 // ErrorFormatter is func for format error.
-type ErrorFormatter func(xerrors.Printer, *Err) (next error)
+//
+// Use AdaptFormatter for funcs take golang.org/x/xerrors.Printer.
+type ErrorFormatter func(Printer, *Err) (next error)
 
 // NewFormatter returns
 func NewFormatter(sep, labelSep string) ErrorFormatter {
-	return func(p xerrors.Printer, e *Err) (next error) {
+	return func(p Printer, e *Err) (next error) {
 		p.Print(e.msg)
 		if p.Detail() {
 			p.Print(sep, "priority", labelSep, e.priority)
-			parent := e.parent
-			for {
-				if parent == nil {
-					break
-				}
+			for _, parent := range e.parents() {
 				p.Print(sep, "parent", labelSep, parent.msg)
-				parent = parent.parent
 			}
-			p.Print(sep, "callers", labelSep, e.callers.String())
-			for _, v := range e.values {
-				p.Print(sep, v.Label, labelSep, v.Value)
+			p.Print(sep, "callers", labelSep, e.callerFormat.format(e.callers))
+			if !e.time.IsZero() {
+				p.Print(sep, "time", labelSep, e.time.Format(time.RFC3339Nano))
+			}
+			if e.id != "" {
+				p.Print(sep, "error_id", labelSep, e.id)
+			}
+			for _, v := range flattenValues(e.limits.Values(e.values)) {
+				p.Print(sep, v.Label, labelSep, v.Text())
 			}
 			return e.wrappedError
 		}
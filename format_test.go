@@ -0,0 +1,29 @@
+package aerrors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestErr_Format_branches(t *testing.T) {
+	conf := DefaultConfig.Clone().WithClock(testClock).WithIDGenerator(testIDGenerator)
+	err := conf.Errorf("%w and %w", conf.Error("not found"), errors.New("timeout"))
+
+	got := fmt.Sprintf("%+v", err)
+	want := regexp.MustCompile(`^not found and timeout:
+    priority: Error
+    callers: aerrors.TestErr_Format_branches:.+/format_test.go:\d+
+    time: 2001-02-03T04:05:06\.000000007Z
+    error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV
+    - not found:
+          priority: Error
+          callers: aerrors.TestErr_Format_branches:.+/format_test.go:\d+
+          time: 2001-02-03T04:05:06\.000000007Z
+          error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV
+    - timeout$`)
+	if !want.MatchString(got) {
+		t.Errorf("%%+v:\n%s", got)
+	}
+}
@@ -1,5 +1,3 @@
 module github.com/kamiaka/aerrors
 
-go 1.15
-
-require golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1
+go 1.21
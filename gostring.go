@@ -0,0 +1,83 @@
+package aerrors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GoString returns Go-literal-like representation of the error for debugging and test diffs.
+// It is used for `%#v`.
+//
+// It contains the message, priority, values, message of the parent and wrapped error.
+// Callers are not contained, so the representation is reproducible.
+//
+//	&aerrors.Err{Message: "error: oops", Priority: aerrors.Error, Parent: "app error", Values: []*aerrors.Value{{Label: "id", Value: "42"}}, Wrapped: &errors.errorString{s:"oops"}}
+//
+// Errors in a cycle are represented as CycleMarker.
+func (e *Err) GoString() string {
+	return e.goString(newVisitor())
+}
+
+func (e *Err) goString(v *visitor) string {
+	if marker, ok := v.visit(e); !ok {
+		return marker
+	}
+	var b strings.Builder
+	b.WriteString("&aerrors.Err{Message: ")
+	fmt.Fprintf(&b, "%q", e.msg)
+	b.WriteString(", Priority: ")
+	b.WriteString(e.priority.GoString())
+	if e.parent != nil {
+		fmt.Fprintf(&b, ", Parent: %q", e.parent.msg)
+	}
+	if len(e.values) > 0 {
+		b.WriteString(", Values: []*aerrors.Value{")
+		for i, v := range e.values {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(v.goString())
+		}
+		b.WriteString("}")
+	}
+	if e.wrappedError != nil {
+		b.WriteString(", Wrapped: ")
+		b.WriteString(goStringError(e.wrappedError, v))
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// goStringError returns Go syntax of the error.
+// Wrapping errors are represented as fmt.Errorf and multiple errors as errors.Join to avoid printing pointers.
+func goStringError(err error, v *visitor) string {
+	switch u := err.(type) {
+	case *Err:
+		return u.goString(v)
+	case fmt.GoStringer:
+	case interface{ Unwrap() []error }:
+		if marker, ok := v.visit(err); !ok {
+			return marker
+		}
+		errs := u.Unwrap()
+		s := make([]string, len(errs))
+		for i, err := range errs {
+			s[i] = goStringError(err, v.fork())
+		}
+		return "errors.Join(" + strings.Join(s, ", ") + ")"
+	case interface{ Unwrap() error }:
+		if marker, ok := v.visit(err); !ok {
+			return marker
+		}
+		inner := u.Unwrap()
+		if inner == nil {
+			break
+		}
+		msg, format := err.Error(), fmt.Sprintf("%q", err.Error())
+		if prefix, ok := strings.CutSuffix(msg, inner.Error()); ok {
+			format = fmt.Sprintf("%q", strings.ReplaceAll(prefix, "%", "%%")+"%w")
+		}
+		return "fmt.Errorf(" + format + ", " + goStringError(inner, v) + ")"
+	}
+	return fmt.Sprintf("%#v", err)
+}
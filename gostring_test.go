@@ -0,0 +1,44 @@
+package aerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ExampleErr_GoString() {
+	appError := New("app error")
+	err := appError.Errorf("error: %w", errors.New("oops")).WithInt("id", 42)
+
+	fmt.Printf("%#v\n", err)
+	fmt.Printf("%#v\n", New("other", Priority(ErrorPriority(42))).Wrap(err))
+	// Output:
+	// &aerrors.Err{Message: "error: oops", Priority: aerrors.Error, Parent: "app error", Values: []*aerrors.Value{{Label: "id", Value: "42"}}, Wrapped: &errors.errorString{s:"oops"}}
+	// &aerrors.Err{Message: "other", Priority: aerrors.ErrorPriority(42), Parent: "other", Wrapped: &aerrors.Err{Message: "error: oops", Priority: aerrors.Error, Parent: "app error", Values: []*aerrors.Value{{Label: "id", Value: "42"}}, Wrapped: &errors.errorString{s:"oops"}}}
+}
+
+func ExampleErr_GoString_joined() {
+	err := Errorf("%w and %w", errors.New("not found"), New("timeout"))
+
+	fmt.Printf("%#v\n", err)
+	// Output:
+	// &aerrors.Err{Message: "not found and timeout", Priority: aerrors.Error, Wrapped: errors.Join(&errors.errorString{s:"not found"}, &aerrors.Err{Message: "timeout", Priority: aerrors.Error})}
+}
+
+func ExampleErr_GoString_wrapped() {
+	err := New("app error").Wrap(fmt.Errorf("100%% failed: %w", errors.New("oops")))
+
+	fmt.Printf("%#v\n", err)
+	// Output:
+	// &aerrors.Err{Message: "app error", Priority: aerrors.Error, Parent: "app error", Wrapped: fmt.Errorf("100%% failed: %w", &errors.errorString{s:"oops"})}
+}
+
+func TestErr_GoString_wrappedCycle(t *testing.T) {
+	err := New("a", CallerDepth(0))
+	err.WithError(fmt.Errorf("ctx: %w", err))
+
+	want := `&aerrors.Err{Message: "a", Priority: aerrors.Error, Wrapped: fmt.Errorf("ctx: %w", <cycle>)}`
+	if got := fmt.Sprintf("%#v", err); got != want {
+		t.Errorf("%%#v == %s, want %s", got, want)
+	}
+}
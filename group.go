@@ -0,0 +1,84 @@
+package aerrors
+
+// GroupSeparator separates labels of a group and its values in flattened labels, e.g. "db.query".
+const GroupSeparator = "."
+
+// Group returns Value that nests `values` under the label `l`.
+//
+// Text formatters render values of the group with prefixed labels (e.g. "db.query: ..."),
+// and structured encoders render them as a nested object.
+func Group(l string, values ...*Value) *Value {
+	return &Value{
+		Label: l,
+		Kind:  KindGroup,
+		Group: values,
+	}
+}
+
+// Group appends group Value and return Values.
+func (ls Values) Group(l string, values ...*Value) Values {
+	return append(ls, Group(l, values...))
+}
+
+// Flatten returns values of the group with labels prefixed by the group's label recursively.
+// It returns the receiver itself if it is not a group.
+func (v *Value) Flatten() []*Value {
+	if v.Kind != KindGroup {
+		return []*Value{v}
+	}
+	var flat []*Value
+	for _, g := range v.Group {
+		for _, f := range g.Flatten() {
+			prefixed := *f
+			prefixed.Label = prefixLabel(v.Label, f.Label)
+			flat = append(flat, &prefixed)
+		}
+	}
+	return flat
+}
+
+func flattenValues(values []*Value) []*Value {
+	var flat []*Value
+	for _, v := range values {
+		flat = append(flat, v.Flatten()...)
+	}
+	return flat
+}
+
+func prefixLabel(prefix, label string) string {
+	if prefix == "" {
+		return label
+	}
+	return prefix + GroupSeparator + label
+}
+
+// WithGroup appends group Value and returns receiver.
+func (e *Err) WithGroup(l string, values ...*Value) *Err {
+	return e.addValues(Group(l, values...))
+}
+
+// WithAny appends Value converted via reflection and returns receiver.
+func (e *Err) WithAny(l string, v interface{}) *Err {
+	return e.addValues(Any(l, v))
+}
+
+// WithStruct appends Value converted from the struct and returns receiver.
+func (e *Err) WithStruct(l string, v interface{}) *Err {
+	return e.addValues(Struct(l, v))
+}
+
+// addValues appends values with labels prefixed by the namespace of the error,
+// and bytes Values rendered by BytesFormat of the error.
+// Bytes Values are rendered here, and their bytes are dropped.
+func (e *Err) addValues(values ...*Value) *Err {
+	for _, v := range values {
+		v = e.bytesFormat.apply(v)
+		if e.namespace != "" {
+			prefixed := *v
+			prefixed.Label = prefixLabel(e.namespace, v.Label)
+			v = &prefixed
+		}
+		e.values = append(e.values, v)
+	}
+	return e
+}
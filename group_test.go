@@ -0,0 +1,81 @@
+package aerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ExampleGroup() {
+	err := New("query failed", CallerDepth(0), Clock(testClock), GenerateID(testIDGenerator)).
+		WithGroup("db", String("table", "users"), Group("query", String("sql", "SELECT 1"), Int("rows", 0)))
+
+	fmt.Println(err.Values()[0])
+	b, _ := json.Marshal(err)
+	fmt.Println(string(b))
+	// Output:
+	// db.table: users, db.query.sql: SELECT 1, db.query.rows: 0
+	// {"message":"query failed","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"db":{"table":"users","query":{"sql":"SELECT 1","rows":"0"}}}}
+}
+
+func ExampleNamespace() {
+	dbError := New("db error", Namespace("db"), CallerDepth(0))
+	err := dbError.New("not found").WithString("id", "42").WithValue(String("table", "users"))
+
+	for _, v := range err.Values() {
+		fmt.Println(v)
+	}
+	// Output:
+	// db.id: 42
+	// db.table: users
+}
+
+func TestValue_Flatten(t *testing.T) {
+	cases := []struct {
+		value *Value
+		want  []string
+	}{
+		{value: String("id", "42"), want: []string{"id: 42"}},
+		{value: Group("db"), want: nil},
+		{value: Group("db", String("id", "42"), Group("", String("table", "users"))), want: []string{"db.id: 42", "db.table: users"}},
+	}
+
+	for i, tc := range cases {
+		var got []string
+		for _, v := range tc.value.Flatten() {
+			got = append(got, v.String())
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("#%d: Flatten()\ngot:  %#v\nwant: %#v", i, got, tc.want)
+		}
+	}
+
+	value := String("id", "42")
+	New("error", Namespace("db")).WithValue(value)
+	if value.Label != "id" {
+		t.Errorf("label of the added value is changed: %q", value.Label)
+	}
+}
+
+func TestErr_Format_group(t *testing.T) {
+	err := New("query failed").WithGroup("db", String("table", "users"), Group("query", String("sql", "SELECT 1")))
+
+	got := fmt.Sprintf("%+v", err)
+	want := "\n    db.table: users\n    db.query.sql: SELECT 1"
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("%%+v\ngot:  %q\nwant suffix: %q", got, want)
+	}
+}
+
+func TestValue_GoString_group(t *testing.T) {
+	got := fmt.Sprintf("%#v", Group("db", String("id", "42")))
+	want := `&aerrors.Value{Label: "db", Kind: aerrors.KindGroup, Group: []*aerrors.Value{{Label: "id", Value: "42"}}}`
+	if got != want {
+		t.Errorf("GoString()\ngot:  %s\nwant: %s", got, want)
+	}
+	if got, want := Group("db", String("id", "42"), String("table", "users")).String(), "db.id: 42, db.table: users"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package aerrors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Hook is called when new error is created by the Config or its children.
+//
+// It is called after the error is initialized, before it is returned to the caller.
+// A panic in the hook is recovered and ignored.
+type Hook func(*Err)
+
+// hookSet is hooks of the Config.
+//
+// Hooks of the config it is cloned from are also called, and read when the error is created,
+// so hooks registered later to the config apply to errors created before, e.g. package-level sentinel errors.
+type hookSet struct {
+	parent *hookSet
+	mu     sync.Mutex
+	hooks  atomic.Pointer[[]Hook]
+}
+
+// child returns hookSet that calls hooks of `s` and its own hooks.
+func (s *hookSet) child() *hookSet {
+	return &hookSet{parent: s}
+}
+
+func (s *hookSet) add(hooks []Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var added []Hook
+	if old := s.hooks.Load(); old != nil {
+		added = append(added, *old...)
+	}
+	added = append(added, hooks...)
+	s.hooks.Store(&added)
+}
+
+// all returns hooks from the root config's.
+func (s *hookSet) all() []Hook {
+	if s == nil {
+		return nil
+	}
+	hooks := s.parent.all()
+	if own := s.hooks.Load(); own != nil {
+		hooks = append(hooks, *own...)
+	}
+	return hooks
+}
+
+func (s *hookSet) run(e *Err) {
+	if s == nil {
+		return
+	}
+	s.parent.run(e)
+	if own := s.hooks.Load(); own != nil {
+		for _, hook := range *own {
+			callHook(hook, e)
+		}
+	}
+}
+
+func (e *Err) runHooks() {
+	e.childConf.hooks.run(e)
+}
+
+func callHook(hook Hook, e *Err) {
+	defer func() {
+		_ = recover()
+	}()
+	hook(e)
+}
@@ -0,0 +1,57 @@
+package aerrors
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func ExampleHooks() {
+	count := 0
+	appError := New("app error", Hooks(func(e *Err) {
+		count++
+		fmt.Printf("created: %v\n", e)
+	}, func(*Err) {
+		panic("ignored")
+	}))
+
+	appError.New("oops")
+	appError.Errorf("error: %d", 42)
+	appError.Wrap(errors.New("origin"))
+	New("without hooks")
+
+	fmt.Println(count)
+	// Output:
+	// created: app error
+	// created: oops
+	// created: error: 42
+	// created: app error
+	// 4
+}
+
+func TestConfig_WithHook_sentinel(t *testing.T) {
+	conf := DefaultConfig.Clone()
+	notFound := conf.Error("not found")
+
+	var created []string
+	conf.WithHook(func(e *Err) {
+		created = append(created, e.Error())
+	})
+	clone := conf.Clone().WithHook(func(e *Err) {
+		created = append(created, "clone: "+e.Error())
+	})
+
+	notFound.New("user 1")
+	notFound.Errorf("user %d", 2)
+	clone.Error("by clone")
+	conf.Error("by conf")
+
+	want := []string{"user 1", "user 2", "by clone", "clone: by clone", "by conf"}
+	if !reflect.DeepEqual(created, want) {
+		t.Errorf("created = %#v, want %#v", created, want)
+	}
+	if got := len(clone.Hooks()); got != 2 {
+		t.Errorf("len(clone.Hooks()) = %d, want 2", got)
+	}
+}
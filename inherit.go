@@ -0,0 +1,47 @@
+package aerrors
+
+// ValueInheritancePolicy is the policy of values inherited by children from the parent.
+type ValueInheritancePolicy int
+
+// Value inheritance policies.
+const (
+	// InheritValues shares the parent's values with the child.
+	// Values appended to the child or the parent later are not shared.
+	InheritValues ValueInheritancePolicy = iota
+	// CopyValues copies the parent's values to the child,
+	// so modifying the child's value (e.g. by WithLabel) does not affect the parent.
+	CopyValues
+	// NoValues does not inherit the parent's values.
+	// They are still available by AllValues.
+	NoValues
+)
+
+func copyValue(v *Value) *Value {
+	copy := *v
+	if v.Group != nil {
+		copy.Group = make([]*Value, len(v.Group))
+		for i, g := range v.Group {
+			copy.Group[i] = copyValue(g)
+		}
+	}
+	return &copy
+}
+
+func (p ValueInheritancePolicy) inherit(values []*Value) []*Value {
+	switch p {
+	case CopyValues:
+		if len(values) == 0 {
+			return nil
+		}
+		copied := make([]*Value, len(values))
+		for i, v := range values {
+			copied[i] = copyValue(v)
+		}
+		return copied
+	case NoValues:
+		return nil
+	default:
+		// clip the capacity, so that appending to the child never overwrites the parent's backing array.
+		return values[:len(values):len(values)]
+	}
+}
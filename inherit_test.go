@@ -0,0 +1,55 @@
+package aerrors
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestValueInheritancePolicy(t *testing.T) {
+	cases := []struct {
+		policy ValueInheritancePolicy
+		want   []string
+	}{
+		{policy: InheritValues, want: []string{"id: 1", "child: c"}},
+		{policy: CopyValues, want: []string{"id: 1", "child: c"}},
+		{policy: NoValues, want: []string{"child: c"}},
+	}
+
+	for i, tc := range cases {
+		parent := make([]*Value, 0, 4)
+		p := New("parent").WithValue(append(parent, String("id", "1"))...)
+		c := p.New("child", ValueInheritance(tc.policy)).WithString("child", "c")
+		p.WithString("parent", "p")
+
+		var got []string
+		for _, v := range c.Values() {
+			got = append(got, v.String())
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("#%d: child values\ngot:  %#v\nwant: %#v", i, got, tc.want)
+		}
+		if n := len(p.Values()); n != 2 {
+			t.Errorf("#%d: len(parent values) = %d, want 2", i, n)
+		}
+		if tc.policy == CopyValues {
+			c.Values()[0].WithLabel("changed")
+			if l := p.Values()[0].Label; l != "id" {
+				t.Errorf("#%d: parent label = %q, want %q", i, l, "id")
+			}
+		}
+	}
+}
+
+func ExampleErr_AllValues() {
+	root := New("root", ValueInheritance(NoValues)).WithString("id", "root").WithString("service", "api")
+	child := root.New("child").WithString("id", "child").WithString("query", "SELECT 1")
+
+	for _, v := range child.AllValues() {
+		fmt.Println(v)
+	}
+	// Output:
+	// id: child
+	// service: api
+	// query: SELECT 1
+}
@@ -0,0 +1,80 @@
+package aerrors
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"sync/atomic"
+	"time"
+)
+
+// IDGenerator returns the unique ID of the error created at `t`.
+type IDGenerator func(t time.Time) string
+
+// crockford is Crockford's base32 alphabet used by NewID.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// idCounter orders IDs generated in the same millisecond. It starts at random to avoid collisions between processes.
+var idCounter atomic.Uint32
+
+func init() {
+	idCounter.Store(rand.Uint32() >> 1)
+}
+
+// NewID returns ULID-like ID of the error created at `t`.
+//
+// The ID is 26 characters of Crockford's base32 encoding 48 bits of the Unix time in milliseconds,
+// 32 bits of the counter and 48 bits of randomness, so IDs are sorted by the time.
+// IDs generated in the same millisecond increase in the process until the counter wraps around.
+// It takes no lock and the randomness is not cryptographically secure.
+func NewID(t time.Time) string {
+	ms := uint64(t.UnixMilli())
+	r := rand.Uint64()
+
+	var id [16]byte
+	binary.BigEndian.PutUint16(id[:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	binary.BigEndian.PutUint32(id[6:10], idCounter.Add(1))
+	binary.BigEndian.PutUint16(id[10:12], uint16(r>>32))
+	binary.BigEndian.PutUint32(id[12:], uint32(r))
+	return encodeID(id)
+}
+
+// encodeID encodes 128 bits `id` to 26 characters, the first character has the highest 3 bits.
+func encodeID(id [16]byte) string {
+	var s [26]byte
+	for i := range s {
+		var c byte
+		for bit := i*5 - 2; bit < i*5+3; bit++ {
+			c <<= 1
+			if bit >= 0 && id[bit/8]&(0x80>>(bit%8)) != 0 {
+				c |= 1
+			}
+		}
+		s[i] = crockford[c]
+	}
+	return string(s[:])
+}
+
+// Time returns the time when the error is created.
+func (e *Err) Time() time.Time {
+	return e.time
+}
+
+// ID returns the unique ID of the error, that is useful to reference the occurrence, e.g. in support tickets.
+// It is empty if IDGenerator of the config is nil.
+func (e *Err) ID() string {
+	return e.id
+}
+
+// stamp sets the creation time and the ID of the error by the config.
+func (e *Err) stamp(conf *Config) {
+	now := conf.clock
+	if now == nil {
+		now = time.Now
+	}
+	e.time = now()
+	e.id = ""
+	if conf.idGenerator != nil {
+		e.id = conf.idGenerator(e.time)
+	}
+}
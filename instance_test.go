@@ -0,0 +1,111 @@
+package aerrors
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testClock and testIDGenerator stamp errors with the fixed time and ID, so that detailed outputs are deterministic.
+var (
+	testClock       = func() time.Time { return time.Date(2001, time.February, 3, 4, 5, 6, 7, time.UTC) }
+	testIDGenerator = func(time.Time) string { return "01ARZ3NDEKTSV4RRFFQ69G5FAV" }
+)
+
+func ExampleErr_ID() {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	appError := New("app error", Clock(func() time.Time { return now }), GenerateID(func(t time.Time) string {
+		return fmt.Sprintf("err-%d", t.Unix())
+	}))
+	err := appError.New("oops")
+
+	fmt.Println(err.Time())
+	fmt.Println(err.ID())
+	// Output:
+	// 2026-10-19 12:00:00 +0000 UTC
+	// err-1792411200
+}
+
+func TestNewID(t *testing.T) {
+	format := regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	prev := NewID(now.Add(-time.Millisecond))
+	for i := 0; i < 100; i++ {
+		id := NewID(now)
+		if !format.MatchString(id) {
+			t.Fatalf("NewID() = %q, want 26 characters of Crockford's base32", id)
+		}
+		if id <= prev {
+			t.Fatalf("NewID() = %q, want greater than %q", id, prev)
+		}
+		prev = id
+	}
+
+	if got, want := NewID(time.UnixMilli(0))[:10], "0000000000"; got != want {
+		t.Errorf("time part of NewID(0) = %q, want %q", got, want)
+	}
+	if got, want := NewID(time.UnixMilli(1<<48 - 1))[:10], "7ZZZZZZZZZ"; got != want {
+		t.Errorf("time part of NewID(max) = %q, want %q", got, want)
+	}
+}
+
+func TestNewID_parallel(t *testing.T) {
+	now := time.Now()
+	ids := make(chan string, 1000)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ids <- NewID(now)
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := map[string]bool{}
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("NewID() = %q, generated twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestErr_Time(t *testing.T) {
+	var now time.Time
+	clock := func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	appError := New("app error", Clock(clock), GenerateID(nil))
+	children := []*Err{
+		appError.New("new"),
+		appError.Errorf("errorf"),
+		appError.Wrap(fmt.Errorf("wrap")),
+		appError.ChildConfig().Error("config error"),
+	}
+
+	if appError.ID() != "" {
+		t.Errorf("ID() = %q, want empty", appError.ID())
+	}
+	var zero time.Time
+	for i, child := range children {
+		if want := zero.Add(time.Duration(i+2) * time.Second); !child.Time().Equal(want) {
+			t.Errorf("#%d: Time() = %v, want %v", i, child.Time(), want)
+		}
+	}
+
+	before := time.Now()
+	if err := New("error", Clock(nil)); err.Time().Before(before) || len(err.ID()) != 26 {
+		t.Errorf("Time() = %v, ID() = %q", err.Time(), err.ID())
+	}
+	if err := New("error"); err.Time().Before(before) || len(err.ID()) != 26 {
+		t.Errorf("default: Time() = %v, ID() = %q", err.Time(), err.ID())
+	}
+}
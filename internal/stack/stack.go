@@ -8,38 +8,58 @@ import (
 
 // Frames ...
 type Frames struct {
-	*runtime.Frames
+	pcs []uintptr
 }
 
 // Callers ...
 func Callers(depth, skip int) *Frames {
 	pc := make([]uintptr, depth)
 
-	runtime.Callers(skip+2, pc)
+	n := runtime.Callers(skip+2, pc)
 
 	return &Frames{
-		runtime.CallersFrames(pc),
+		pcs: pc[:n],
 	}
 }
 
-// Format frames to string by specified separators.
-func (f *Frames) Format(sep, funcSep, lineSep string) string {
-	var b bytes.Buffer
-	inMore := false
+// Frames returns new iterator of frames.
+// It can be called any number of times.
+func (f *Frames) Frames() *runtime.Frames {
+	return runtime.CallersFrames(f.pcs)
+}
+
+// List returns frames until runtime.main.
+func (f *Frames) List() []runtime.Frame {
+	var list []runtime.Frame
+	frames := f.Frames()
 	for {
-		frame, more := f.Next()
-		if frame.Function == "runtime.main" {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.main" || frame.PC == 0 {
 			break
 		}
-		if inMore {
-			b.WriteString(sep)
+		list = append(list, frame)
+		if !more {
+			break
 		}
-		b.WriteString(simpleFunc(frame.Function) + funcSep + frame.File + lineSep + strconv.Itoa(frame.Line))
-		if more {
-			inMore = true
-			continue
+	}
+	return list
+}
+
+// Format frames to string by specified separators.
+func (f *Frames) Format(sep, funcSep, lineSep string) string {
+	return f.FormatFunc(sep, func(frame runtime.Frame) string {
+		return SimpleFunc(frame.Function) + funcSep + frame.File + lineSep + strconv.Itoa(frame.Line)
+	})
+}
+
+// FormatFunc formats frames by `format` and joins them with `sep`.
+func (f *Frames) FormatFunc(sep string, format func(runtime.Frame) string) string {
+	var b bytes.Buffer
+	for i, frame := range f.List() {
+		if i > 0 {
+			b.WriteString(sep)
 		}
-		break
+		b.WriteString(format(frame))
 	}
 
 	return b.String()
@@ -2,13 +2,25 @@ package stack
 
 import "strings"
 
-// simpleFunc returns path removed function name.
+// SimpleFunc returns path removed function name.
 //   e.g.,
 //     given:  path/to/pkgdir.(Type.)Method
 //     return: pkgdir.(Type.)Method
-func simpleFunc(funcName string) string {
+func SimpleFunc(funcName string) string {
 	if index := strings.LastIndex(funcName, "/"); index >= 0 {
 		return funcName[index+1:]
 	}
 	return funcName
 }
+
+// PackagePath returns package path of the function name.
+//   e.g.,
+//     given:  path/to/pkgdir.(Type.)Method
+//     return: path/to/pkgdir
+func PackagePath(funcName string) string {
+	slash := strings.LastIndex(funcName, "/")
+	if dot := strings.Index(funcName[slash+1:], "."); dot >= 0 {
+		return funcName[:slash+1+dot]
+	}
+	return funcName
+}
@@ -0,0 +1,200 @@
+package aerrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type jsonErr struct {
+	Message  string       `json:"message"`
+	Template string       `json:"template,omitempty"`
+	Priority string       `json:"priority"`
+	Parents  []string     `json:"parents,omitempty"`
+	Callers  []jsonFrame  `json:"callers,omitempty"`
+	Time     string       `json:"time,omitempty"`
+	ID       string       `json:"error_id,omitempty"`
+	Values   jsonValues   `json:"values,omitempty"`
+	Wrapped  *jsonWrapped `json:"wrapped,omitempty"`
+
+	// Truncated is the size of JSON exceeding Limits.MaxBytes.
+	Truncated int `json:"truncated,omitempty"`
+}
+
+type jsonFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// jsonWrapped is encoded to *Err's JSON, or message and wrapped errors for other errors.
+// Errors in a cycle or deeper than MaxChainDepth are encoded as the marker message.
+type jsonWrapped struct {
+	err     error
+	visited *visitor
+}
+
+func newJSONWrapped(err error, v *visitor) *jsonWrapped {
+	if err == nil {
+		return nil
+	}
+	return &jsonWrapped{err: err, visited: v}
+}
+
+func (w *jsonWrapped) MarshalJSON() ([]byte, error) {
+	if marker, ok := w.visited.visit(w.err); !ok {
+		return json.Marshal(struct {
+			Message string `json:"message"`
+		}{marker})
+	}
+	if e, ok := w.err.(*Err); ok {
+		return json.Marshal(e.toJSON(w.visited))
+	}
+	j := struct {
+		Message string         `json:"message"`
+		Wrapped *jsonWrapped   `json:"wrapped,omitempty"`
+		Errors  []*jsonWrapped `json:"errors,omitempty"`
+	}{
+		Message: w.err.Error(),
+		Wrapped: newJSONWrapped(errors.Unwrap(w.err), w.visited),
+	}
+	if multi, ok := w.err.(interface{ Unwrap() []error }); ok {
+		for _, err := range multi.Unwrap() {
+			if err != nil {
+				j.Errors = append(j.Errors, newJSONWrapped(err, w.visited.fork()))
+			}
+		}
+	}
+	return json.Marshal(j)
+}
+
+// jsonValues is encoded to JSON object keeping order of values.
+type jsonValues []*Value
+
+func (vs jsonValues) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, v := range vs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		label, err := json.Marshal(v.Label)
+		if err != nil {
+			return nil, err
+		}
+		var value []byte
+		switch v.Kind {
+		case KindGroup, KindMap:
+			value, err = json.Marshal(jsonValues(v.Group))
+		case KindStrings:
+			s := make([]string, len(v.Group))
+			for i, g := range v.Group {
+				s[i] = g.Text()
+			}
+			value, err = json.Marshal(s)
+		default:
+			value, err = json.Marshal(v.Text())
+		}
+		if err != nil {
+			return nil, err
+		}
+		b.Write(label)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// MarshalJSON implements interface `json.Marshaler`.
+//
+// The error is encoded to the object has message, template if it differs from the message, priority, parents' messages, callers,
+// time, error_id, values and wrapped error.
+// The wrapped error is encoded recursively. Errors other than *Err are encoded as the message and its wrapped errors.
+//
+// Errors in a cycle are encoded as the object has only CycleMarker message.
+// The output is limited by Limits of the config.
+func (e *Err) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(newJSONWrapped(e, e.limits.visitor()))
+	if err != nil || e.limits.MaxBytes <= 0 || len(b) <= e.limits.MaxBytes {
+		return b, err
+	}
+	return e.truncatedJSON(len(b))
+}
+
+// truncatedJSON returns JSON has only the message, the priority, the time, the ID and the size of the original JSON.
+// The message is truncated to fit Limits.MaxBytes as possible.
+// The time and then the ID are dropped if the message does not fit with them.
+func (e *Err) truncatedJSON(size int) ([]byte, error) {
+	j := &jsonErr{
+		Priority:  e.priority.String(),
+		Time:      e.jsonTime(),
+		ID:        e.id,
+		Truncated: size,
+	}
+	for {
+		b, err := e.fitJSON(j)
+		if err != nil || j.Message != "" || j.Time == "" && j.ID == "" {
+			return b, err
+		}
+		if j.Time != "" {
+			j.Time = ""
+		} else {
+			j.ID = ""
+		}
+	}
+}
+
+// fitJSON sets the message truncated to fit Limits.MaxBytes to `j` and returns its JSON.
+func (e *Err) fitJSON(j *jsonErr) ([]byte, error) {
+	j.Message = e.msg
+	for n := len(e.msg); ; {
+		b, err := json.Marshal(j)
+		over := len(b) - e.limits.MaxBytes
+		if err != nil || over <= 0 || j.Message == "" {
+			return b, err
+		}
+		if n -= over; n <= 0 {
+			j.Message = ""
+			continue
+		}
+		j.Message = truncate(e.msg, n)
+	}
+}
+
+func (e *Err) toJSON(v *visitor) *jsonErr {
+	j := &jsonErr{
+		Message:  e.msg,
+		Priority: e.priority.String(),
+		Values:   e.limits.Values(e.values),
+	}
+	if e.template != e.msg {
+		j.Template = e.template
+	}
+	for _, parent := range e.parents() {
+		j.Parents = append(j.Parents, parent.msg)
+	}
+	for _, frame := range e.callers.List() {
+		f := jsonFrame{
+			Function: frame.Function,
+			File:     e.callerFormat.file(frame),
+		}
+		if e.callerFormat.line {
+			f.Line = frame.Line
+		}
+		j.Callers = append(j.Callers, f)
+	}
+	j.Time = e.jsonTime()
+	j.ID = e.id
+	j.Wrapped = newJSONWrapped(e.wrappedError, v)
+	return j
+}
+
+// jsonTime returns the time formatted in RFC 3339, or empty if it is zero.
+func (e *Err) jsonTime() string {
+	if e.time.IsZero() {
+		return ""
+	}
+	return e.time.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,43 @@
+package aerrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func ExampleErr_MarshalJSON() {
+	appError := New("app error", CallerDepth(0), Clock(testClock), GenerateID(testIDGenerator))
+	err := appError.Errorf("error: %w", fmt.Errorf("query: %w", errors.New("oops"))).WithInt("id", 42)
+
+	b, _ := json.Marshal(err)
+	fmt.Println(string(b))
+	// Output:
+	// {"message":"error: query: oops","template":"error: %w","priority":"Error","parents":["app error"],"time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"id":"42"},"wrapped":{"message":"query: oops","wrapped":{"message":"oops"}}}
+}
+
+func TestErr_MarshalJSON(t *testing.T) {
+	err := New("new error").Wrap(New("origin", Priority(Info)).WithString("label", "\"value\""))
+
+	b, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+
+	want := regexp.MustCompile(`^\{"message":"new error","priority":"Error","parents":\["new error"\],"callers":\[\{"function":"github.com/kamiaka/aerrors.TestErr_MarshalJSON","file":"[^"]+/json_test.go","line":\d+\}\],"time":"[^"]+","error_id":"[0-9A-Z]{26}","wrapped":\{"message":"origin","priority":"Info","callers":\[[^\]]+\],"time":"[^"]+","error_id":"[0-9A-Z]{26}","values":\{"label":"\\"value\\""\}\}\}$`)
+	if !want.Match(b) {
+		t.Errorf("json.Marshal(err) == %s", b)
+	}
+}
+
+func ExampleErr_MarshalJSON_joined() {
+	conf := DefaultConfig.Clone().WithCallerDepth(0).WithClock(testClock).WithIDGenerator(testIDGenerator)
+	err := conf.Errorf("%w and %w", errors.New("not found"), errors.New("timeout"))
+
+	b, _ := json.Marshal(err)
+	fmt.Println(string(b))
+	// Output:
+	// {"message":"not found and timeout","template":"%w and %w","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","wrapped":{"message":"not found\ntimeout","errors":[{"message":"not found"},{"message":"timeout"}]}}
+}
@@ -0,0 +1,141 @@
+package aerrors
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// LazyMarker is rendered by GoString in place of lazy values, that are not evaluated by it.
+const LazyMarker = "<lazy>"
+
+// lazyValue is the value evaluated at most once when it is rendered.
+type lazyValue struct {
+	once sync.Once
+	fn   func() string
+	text string
+
+	// bytes is the source of lazy bytes Values, rendered by BytesFormat of the config.
+	bytes func() []byte
+}
+
+// newLazyBytes returns lazyValue rendering the result of `fn` by `f`.
+func newLazyBytes(fn func() []byte, f BytesFormat) *lazyValue {
+	return &lazyValue{
+		fn:    func() string { return f.Format(fn()) },
+		bytes: fn,
+	}
+}
+
+func (l *lazyValue) get() string {
+	l.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				l.text = fmt.Sprintf("<panic: %v>", r)
+			}
+		}()
+		l.text = l.fn()
+	})
+	return l.text
+}
+
+// Text returns the rendered value.
+//
+// It evaluates the lazy value at most once, so formatters and serializers should use it instead of the field Value.
+// If the lazy function panics, the text is "<panic: ...>".
+func (v *Value) Text() string {
+	if v.lazy != nil {
+		return v.lazy.get()
+	}
+	return v.Value
+}
+
+// Lazy returns Value whose text is the result of `fn`.
+//
+// `fn` is called at most once when the value is rendered by formatters or serializers,
+// so it is suitable for expensive diagnostics that are unnecessary unless the error is printed.
+func Lazy(l string, fn func() string) *Value {
+	return &Value{
+		Label: l,
+		lazy:  &lazyValue{fn: fn},
+	}
+}
+
+// Lazy appends lazy Value and return Values.
+func (ls Values) Lazy(l string, fn func() string) Values {
+	return append(ls, Lazy(l, fn))
+}
+
+// LazyInt returns lazy Value whose text is the result of `fn`.
+func LazyInt(l string, fn func() int) *Value {
+	return Lazy(l, func() string {
+		return strconv.Itoa(fn())
+	})
+}
+
+// LazyInt appends lazy int Value and return Values.
+func (ls Values) LazyInt(l string, fn func() int) Values {
+	return append(ls, LazyInt(l, fn))
+}
+
+// LazyBool returns lazy Value whose text is the result of `fn`.
+func LazyBool(l string, fn func() bool) *Value {
+	return Lazy(l, func() string {
+		return strconv.FormatBool(fn())
+	})
+}
+
+// LazyBool appends lazy bool Value and return Values.
+func (ls Values) LazyBool(l string, fn func() bool) Values {
+	return append(ls, LazyBool(l, fn))
+}
+
+// LazyBytes returns lazy Value whose text is the result of `fn`
+// rendered by BytesFormat of the config it is added to, or as hex.
+func LazyBytes(l string, fn func() []byte) *Value {
+	return &Value{
+		Label: l,
+		Kind:  KindBytes,
+		lazy:  newLazyBytes(fn, BytesFormat{}),
+	}
+}
+
+// LazyBytes appends lazy bytes Value and return Values.
+func (ls Values) LazyBytes(l string, fn func() []byte) Values {
+	return append(ls, LazyBytes(l, fn))
+}
+
+// LazyStringer returns lazy Value whose text is the result of String method of `v`.
+func LazyStringer(l string, v fmt.Stringer) *Value {
+	return Lazy(l, v.String)
+}
+
+// LazyStringer appends lazy stringer Value and return Values.
+func (ls Values) LazyStringer(l string, v fmt.Stringer) Values {
+	return append(ls, LazyStringer(l, v))
+}
+
+// WithLazy appends lazy Value and returns receiver.
+func (e *Err) WithLazy(l string, fn func() string) *Err {
+	return e.addValues(Lazy(l, fn))
+}
+
+// WithLazyInt appends lazy int Value and returns receiver.
+func (e *Err) WithLazyInt(l string, fn func() int) *Err {
+	return e.addValues(LazyInt(l, fn))
+}
+
+// WithLazyBool appends lazy bool Value and returns receiver.
+func (e *Err) WithLazyBool(l string, fn func() bool) *Err {
+	return e.addValues(LazyBool(l, fn))
+}
+
+// WithLazyBytes appends lazy bytes Value and returns receiver.
+func (e *Err) WithLazyBytes(l string, fn func() []byte) *Err {
+	return e.addValues(LazyBytes(l, fn))
+}
+
+// WithLazyStringer appends lazy stringer Value and returns receiver.
+func (e *Err) WithLazyStringer(l string, v fmt.Stringer) *Err {
+	return e.addValues(LazyStringer(l, v))
+}
@@ -0,0 +1,61 @@
+package aerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func ExampleLazy() {
+	calls := 0
+	err := New("buffer overflow", CallerDepth(0), Clock(testClock), GenerateID(testIDGenerator)).WithLazy("dump", func() string {
+		calls++
+		return "00 01 02"
+	})
+	fmt.Println(calls)
+
+	fmt.Println(err.Values()[0])
+	b, _ := json.Marshal(err)
+	fmt.Println(string(b))
+	fmt.Println(calls)
+	// Output:
+	// 0
+	// dump: 00 01 02
+	// {"message":"buffer overflow","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"dump":"00 01 02"}}
+	// 1
+}
+
+func TestLazy(t *testing.T) {
+	cases := []struct {
+		value *Value
+		want  string
+	}{
+		{value: LazyInt("int", func() int { return 42 }), want: "42"},
+		{value: LazyBool("bool", func() bool { return true }), want: "true"},
+		{value: LazyBytes("bytes", func() []byte { return []byte("foo") }), want: "0x666f6f"},
+		{value: LazyStringer("stringer", &fakeStringer{value: "stringer value"}), want: "stringer value"},
+		{value: Lazy("panic", func() string { panic("boom") }), want: "<panic: boom>"},
+		{value: Group("group", Lazy("lazy", func() string { return "value" })).Flatten()[0], want: "value"},
+	}
+
+	for i, tc := range cases {
+		if got := tc.value.Text(); got != tc.want {
+			t.Errorf("#%d: Text() = %q, want %q", i, got, tc.want)
+		}
+	}
+
+	called := false
+	v := Lazy("lazy", func() string {
+		called = true
+		return "value"
+	})
+	if got, want := fmt.Sprintf("%#v", v), `&aerrors.Value{Label: "lazy", Value: <lazy>}`; got != want || called {
+		t.Errorf("%%#v = %s, want %s without evaluation", got, want)
+	}
+
+	err := New("error", Namespace("ns")).WithLazy("panic", func() string { panic("boom") })
+	if got, want := fmt.Sprintf("%+v", err), "\n    ns.panic: <panic: boom>"; !strings.HasSuffix(got, want) {
+		t.Errorf("%%+v = %q, want suffix %q", got, want)
+	}
+}
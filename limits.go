@@ -0,0 +1,114 @@
+package aerrors
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits is the size budget of the error output by NewFormatter, MarshalJSON and LogValue.
+// Zero fields mean unlimited.
+type Limits struct {
+	// MaxValueLen limits bytes of each value text.
+	// The text is truncated with the marker "... (N more bytes)" to fit in MaxValueLen.
+	MaxValueLen int
+	// MaxValues limits the number of values of each error, and members of each group, string slice and string map.
+	// The rest is summarized as the value labeled MoreLabel, e.g. "...: 3 more values".
+	MaxValues int
+	// MaxDepth limits the depth of the wrapped chain. Deeper errors are rendered as DepthMarker.
+	// It is capped by MaxChainDepth.
+	MaxDepth int
+	// MaxBytes limits total bytes of the formatted error, JSON and slog attributes. Outputs never exceed it including markers.
+	// The formatted error is truncated with the marker "... (N more bytes)",
+	// JSON exceeding it is encoded as the object has only the message, the priority and the "truncated" original size,
+	// and slog attributes exceeding it are dropped with "truncated" attribute.
+	MaxBytes int
+}
+
+// truncate returns `s` truncated to `n` bytes including the marker "... (N more bytes)".
+// If `n` is too small for the marker, the marker is shortened to "...", or omitted.
+// It never cuts a rune in the middle.
+func truncate(s string, n int) string {
+	if n <= 0 || len(s) <= n {
+		return s
+	}
+	cut := func(n int) int {
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		return n
+	}
+	for i := cut(n); i >= 0; i = cut(i - 1) {
+		marker := fmt.Sprintf("... (%d more bytes)", len(s)-i)
+		if i+len(marker) <= n {
+			return s[:i] + marker
+		}
+		if i == 0 {
+			break
+		}
+	}
+	if n >= len("...") {
+		return s[:cut(n-len("..."))] + "..."
+	}
+	return s[:cut(n)]
+}
+
+// Depth returns the max depth of chains, that is MaxDepth capped by MaxChainDepth.
+func (l Limits) Depth() int {
+	if l.MaxDepth > 0 && l.MaxDepth < MaxChainDepth {
+		return l.MaxDepth
+	}
+	return MaxChainDepth
+}
+
+// Values returns `values` limited by MaxValues and MaxValueLen.
+// Members of groups, string slices and string maps are limited in the same way.
+// Values are copied if they are limited.
+//
+// It is for encoders other than NewFormatter, MarshalJSON and LogValue, that honor Limits by themselves.
+func (l Limits) Values(values []*Value) []*Value {
+	if l.MaxValues <= 0 && l.MaxValueLen <= 0 {
+		return values
+	}
+	n, more := len(values), 0
+	if l.MaxValues > 0 && n > l.MaxValues {
+		n, more = l.MaxValues, n-l.MaxValues
+	}
+	limited := make([]*Value, n, n+1)
+	for i, v := range values[:n] {
+		limited[i] = l.value(v)
+	}
+	if more > 0 {
+		limited = append(limited, String(MoreLabel, fmt.Sprintf("%d more values", more)))
+	}
+	return limited
+}
+
+// value returns `v` limited by MaxValues and MaxValueLen.
+func (l Limits) value(v *Value) *Value {
+	if v.Group != nil {
+		limited := *v
+		limited.Group = l.Values(v.Group)
+		if v.Kind == KindStrings || v.Kind == KindMap {
+			limited.Value = truncate(joinMembers(v.Kind, limited.Group), l.MaxValueLen)
+		}
+		return &limited
+	}
+	if v.Kind == KindGroup || l.MaxValueLen <= 0 {
+		return v
+	}
+	text := v.Text()
+	if len(text) <= l.MaxValueLen {
+		return v
+	}
+	truncated := *v
+	truncated.lazy = nil
+	truncated.Value = truncate(text, l.MaxValueLen)
+	return &truncated
+}
+
+// visitor returns visitor limited by MaxDepth.
+func (l Limits) visitor() *visitor {
+	v := newVisitor()
+	v.maxDepth = l.MaxDepth
+	return v
+}
@@ -0,0 +1,139 @@
+package aerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func ExampleLimit() {
+	err := New("query failed", CallerDepth(0), Limit(Limits{MaxValueLen: 32, MaxValues: 2}), Clock(testClock), GenerateID(testIDGenerator)).
+		WithString("query", "SELECT * FROM users WHERE deleted_at IS NULL").
+		WithInt("id", 42).
+		WithString("table", "users").
+		WithString("db", "main")
+
+	b, _ := json.Marshal(err)
+	fmt.Println(string(b))
+	// Output:
+	// {"message":"query failed","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"query":"SELECT * FROM... (31 more bytes)","id":"42","...":"2 more values"}}
+}
+
+func TestLimits(t *testing.T) {
+	cases := []struct {
+		limits Limits
+		err    func(opt Option) *Err
+		verb   string
+		want   string
+	}{
+		{
+			limits: Limits{MaxValueLen: 23},
+			err: func(opt Option) *Err {
+				return New("error", opt).WithGroup("g", String("s", "あいうえおかきくけこ"))
+			},
+			verb: "%+v",
+			want: "\n    g.s: あ... (27 more bytes)",
+		},
+		{
+			limits: Limits{MaxDepth: 2},
+			err: func(opt Option) *Err {
+				return New("first", opt).Wrap(New("second").Wrap(New("third")))
+			},
+			verb: "%+v",
+			want: "\n  - <max depth>",
+		},
+		{
+			limits: Limits{MaxBytes: 30},
+			err: func(opt Option) *Err {
+				return New("0123456789abcdefghijklmnopqrstuvwxyz", opt)
+			},
+			verb: "%v",
+			want: "0123456789a... (25 more bytes)",
+		},
+		{
+			limits: Limits{MaxBytes: 10},
+			err: func(opt Option) *Err {
+				return New("0123456789abc", opt)
+			},
+			verb: "%v",
+			want: "0123456...",
+		},
+	}
+
+	for i, tc := range cases {
+		got := fmt.Sprintf(tc.verb, tc.err(Limit(tc.limits)))
+		if !strings.HasSuffix(got, tc.want) {
+			t.Errorf("#%d: %s\ngot:  %q\nwant suffix: %q", i, tc.verb, got, tc.want)
+		}
+		if max := tc.limits.MaxBytes; max > 0 && len(got) > max {
+			t.Errorf("#%d: %s\nlen(%q) == %d, want <= %d", i, tc.verb, got, len(got), max)
+		}
+	}
+}
+
+func TestLimits_members(t *testing.T) {
+	err := New("error", CallerDepth(0), Limit(Limits{MaxValues: 2}), Clock(testClock), GenerateID(testIDGenerator)).
+		WithStrings("tags", []string{"a", "b", "c"}).
+		WithStringMap("headers", map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"})
+
+	b, _ := json.Marshal(err)
+	want := `{"message":"error","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"tags":["a","b","1 more values"],"headers":{"a":"1","b":"2","...":"2 more values"}}}`
+	if string(b) != want {
+		t.Errorf("json = %s\nwant   %s", b, want)
+	}
+
+	got := fmt.Sprintf("%+v", err)
+	if want := "\n    tags: [a, b, 1 more values]\n    headers: {a: 1, b: 2, ...: 2 more values}"; !strings.HasSuffix(got, want) {
+		t.Errorf("%%+v = %q, want suffix %q", got, want)
+	}
+}
+
+func TestErr_LogValue_limits(t *testing.T) {
+	var buf strings.Builder
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	err := New("error", Limit(Limits{MaxBytes: 128}), Clock(testClock), GenerateID(testIDGenerator)).
+		WithString("query", strings.Repeat("x", 100)).
+		WithString("dropped", "value")
+
+	logger.Error("failed", "error", err)
+	want := `{"level":"ERROR","msg":"failed","error":{"message":"error","priority":"Error","time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"query":"xxxxxxxxxxx... (89 more bytes)"},"truncated":true}}` + "\n"
+	if buf.String() != want {
+		t.Errorf("log = %s\nwant  %s", buf.String(), want)
+	}
+}
+
+func TestErr_MarshalJSON_limits(t *testing.T) {
+	err := New(strings.Repeat("a", 100), Limit(Limits{MaxBytes: 80, MaxDepth: 1})).Wrap(New("wrapped"))
+
+	b, jerr := json.Marshal(err)
+	if jerr != nil {
+		t.Fatal(jerr)
+	}
+	if len(b) > 80 {
+		t.Errorf("len(json) = %d, want <= 80: %s", len(b), b)
+	}
+	var j struct {
+		Message   string
+		Truncated int
+	}
+	if jerr := json.Unmarshal(b, &j); jerr != nil {
+		t.Fatal(jerr)
+	}
+	if !strings.HasPrefix(j.Message, "aaa") || !strings.HasSuffix(j.Message, " more bytes)") || j.Truncated <= 80 {
+		t.Errorf("json = %s", b)
+	}
+
+	b, _ = json.Marshal(New("error", CallerDepth(0), Limit(Limits{MaxDepth: 1}), Clock(testClock), GenerateID(testIDGenerator)).Wrap(New("wrapped")))
+	if want := `{"message":"error","priority":"Error","parents":["error"],"time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","wrapped":{"message":"<max depth>"}}`; unescapeHTML(string(b)) != want {
+		t.Errorf("json = %s, want %s", b, want)
+	}
+}
@@ -0,0 +1,300 @@
+package aerrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MessageCatalog provides localized messages.
+type MessageCatalog interface {
+	// Message returns the message for the language `lang` and the message key `key`.
+	// It should not fall back to other languages.
+	Message(lang, key string) (msg *Message, ok bool)
+	// Fallback returns the languages tried when no message is found for the requested language and its parents.
+	Fallback() []string
+}
+
+// Message is localized message template with plural forms.
+//
+// Forms are keyed by plural category, "zero", "one", "two", "few", "many" or "other".
+// Template may contain `{label}` that is replaced by the error's Value.
+type Message struct {
+	Forms map[string]string
+}
+
+// NewMessage returns Message which has only "other" form.
+func NewMessage(template string) *Message {
+	return &Message{
+		Forms: map[string]string{"other": template},
+	}
+}
+
+// Form returns template for the plural category.
+// It falls back to "other" form.
+func (m *Message) Form(category string) string {
+	if s, ok := m.Forms[category]; ok {
+		return s
+	}
+	return m.Forms["other"]
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It accepts a string or an object keyed by plural category.
+func (m *Message) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*m = *NewMessage(s)
+		return nil
+	}
+	forms := map[string]string{}
+	if err := json.Unmarshal(b, &forms); err != nil {
+		return err
+	}
+	m.Forms = forms
+	return nil
+}
+
+// MemoryCatalog is in-memory MessageCatalog.
+type MemoryCatalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]*Message
+	fallback []string
+}
+
+// NewCatalog returns new in-memory catalog.
+// `fallback` languages are tried in order when no message is found.
+func NewCatalog(fallback ...string) *MemoryCatalog {
+	return &MemoryCatalog{
+		messages: map[string]map[string]*Message{},
+		fallback: fallback,
+	}
+}
+
+// Set message for the language and key and returns receiver.
+func (c *MemoryCatalog) Set(lang, key string, msg *Message) *MemoryCatalog {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lang = normalizeLang(lang)
+	if c.messages[lang] == nil {
+		c.messages[lang] = map[string]*Message{}
+	}
+	c.messages[lang][key] = msg
+	return c
+}
+
+// SetString sets message that has only "other" form and returns receiver.
+func (c *MemoryCatalog) SetString(lang, key, template string) *MemoryCatalog {
+	return c.Set(lang, key, NewMessage(template))
+}
+
+// Message implements MessageCatalog.
+func (c *MemoryCatalog) Message(lang, key string) (*Message, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	msg, ok := c.messages[normalizeLang(lang)][key]
+	return msg, ok
+}
+
+// Fallback implements MessageCatalog.
+func (c *MemoryCatalog) Fallback() []string {
+	return c.fallback
+}
+
+// LoadJSON loads messages from JSON.
+//
+// JSON is object keyed by language and message key.
+//
+//	{
+//	  "en": {
+//	    "not_found": "{name} is not found",
+//	    "items": {"one": "{count} item", "other": "{count} items"}
+//	  }
+//	}
+func (c *MemoryCatalog) LoadJSON(b []byte) error {
+	var langs map[string]map[string]*Message
+	if err := json.Unmarshal(b, &langs); err != nil {
+		return err
+	}
+	for lang, messages := range langs {
+		for key, msg := range messages {
+			c.Set(lang, key, msg)
+		}
+	}
+	return nil
+}
+
+// LoadFile loads messages from JSON file. See LoadJSON for the format.
+func (c *MemoryCatalog) LoadFile(name string) error {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return c.LoadJSON(b)
+}
+
+// DefaultCatalog is used when Config has no catalog.
+var DefaultCatalog MessageCatalog = NewCatalog()
+
+// PluralLabel is the label of the Value that selects the plural form.
+var PluralLabel = "count"
+
+// PluralRules returns plural category for the number `n` keyed by primary language.
+//
+// It can overwrite for other languages.
+// Languages not in the map use the rule of "en".
+var PluralRules = map[string]func(n int) string{
+	"en": pluralOneOther,
+	"de": pluralOneOther,
+	"fr": func(n int) string {
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+	"ja": pluralOther,
+	"ko": pluralOther,
+	"zh": pluralOther,
+}
+
+func pluralOneOther(n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+func pluralOther(int) string {
+	return "other"
+}
+
+// PluralCategory returns plural category of the number `n` for the language `lang`.
+func PluralCategory(lang string, n int) string {
+	rule, ok := PluralRules[primaryLang(lang)]
+	if !ok {
+		rule = PluralRules["en"]
+	}
+	if rule == nil {
+		return "other"
+	}
+	return rule(n)
+}
+
+// FallbackChain returns the language and its parents.
+//
+//	e.g., "zh-Hant-TW" returns ["zh-hant-tw", "zh-hant", "zh"]
+func FallbackChain(lang string) []string {
+	lang = normalizeLang(lang)
+	var chain []string
+	for lang != "" {
+		chain = append(chain, lang)
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	return chain
+}
+
+func normalizeLang(lang string) string {
+	return strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+}
+
+func primaryLang(lang string) string {
+	lang = normalizeLang(lang)
+	if i := strings.Index(lang, "-"); i >= 0 {
+		return lang[:i]
+	}
+	return lang
+}
+
+// Localize returns the message localized to the language `lang`.
+//
+// The message is looked up by the message key in the fallback chain of `lang`, then catalog's fallback languages.
+// `{label}` in the message is replaced by the error's Value, and `{0}`, `{1}`, ... are replaced by the arguments of Errorf.
+// The plural form is selected by the Value labeled PluralLabel.
+// If the error has no message key or no message is found, it returns Error().
+func (e *Err) Localize(lang string) string {
+	if e.msgKey == "" {
+		return e.Error()
+	}
+	catalog := e.catalog
+	if catalog == nil {
+		catalog = DefaultCatalog
+	}
+
+	langs := append(FallbackChain(lang), catalog.Fallback()...)
+	for _, l := range langs {
+		msg, ok := catalog.Message(l, e.msgKey)
+		if !ok {
+			continue
+		}
+		category := "other"
+		if v := e.lookupValue(PluralLabel); v != nil {
+			if n, err := strconv.Atoi(v.Text()); err == nil {
+				category = PluralCategory(l, n)
+			}
+		}
+		return expandTemplate(msg.Form(category), e.lookupPlaceholder)
+	}
+	return e.Error()
+}
+
+func (e *Err) lookupPlaceholder(name string) (string, bool) {
+	if i, err := strconv.Atoi(name); err == nil {
+		if i < 0 || i >= len(e.args) {
+			return "", false
+		}
+		return fmt.Sprint(e.args[i]), true
+	}
+	if v := e.lookupValue(name); v != nil {
+		return v.Text(), true
+	}
+	return "", false
+}
+
+// lookupValue returns the value labeled `label` by (*Err).Value, including parents' values.
+// The label prefixed by the namespace of the error takes precedence.
+func (e *Err) lookupValue(label string) *Value {
+	if e.namespace != "" {
+		if v, ok := e.Value(prefixLabel(e.namespace, label)); ok {
+			return v
+		}
+	}
+	v, _ := e.Value(label)
+	return v
+}
+
+// expandTemplate replaces `{name}` in `s` by the result of `lookup`.
+// `{{` and `}}` are replaced by `{` and `}`.
+func expandTemplate(s string, lookup func(name string) (string, bool)) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c == '{' || c == '}') && i+1 < len(s) && s[i+1] == c {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		if c != '{' {
+			b.WriteByte(c)
+			continue
+		}
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			b.WriteString(s[i:])
+			break
+		}
+		if v, ok := lookup(s[i+1 : i+end]); ok {
+			b.WriteString(v)
+		} else {
+			b.WriteString(s[i : i+end+1])
+		}
+		i += end
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package aerrors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func ExampleErr_Localize() {
+	catalog := NewCatalog("en").
+		SetString("en", "not_found", "{name} is not found").
+		SetString("ja", "not_found", "{name} が見つかりません")
+	notFound := New("not found", Catalog(catalog), MessageKey("not_found"))
+
+	err := notFound.Wrap(os.ErrNotExist).WithString("name", "config.json")
+
+	fmt.Println(err)
+	fmt.Println(err.Localize("ja-JP"))
+	fmt.Println(err.Localize("fr"))
+	// Output:
+	// not found
+	// config.json が見つかりません
+	// config.json is not found
+}
+
+func TestErr_Localize(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "messages.json")
+	data := `{
+		"en": {"items": {"one": "{count} item is broken", "other": "{count} items are broken"}},
+		"fr": {"items": {"one": "{count} élément est cassé", "other": "{count} éléments sont cassés"}},
+		"pt-BR": {"items": "{count} itens quebrados"}
+	}`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	catalog := NewCatalog("en")
+	if err := catalog.LoadFile(file); err != nil {
+		t.Fatal(err)
+	}
+	broken := New("broken items", Catalog(catalog), MessageKey("items"))
+
+	cases := []struct {
+		err  *Err
+		lang string
+		want string
+	}{
+		{
+			err:  broken.New("1 item is broken", MessageKey("items")).WithInt("count", 1),
+			lang: "en",
+			want: "1 item is broken",
+		},
+		{
+			err:  broken.New("2 items are broken", MessageKey("items")).WithInt("count", 2),
+			lang: "en-US",
+			want: "2 items are broken",
+		},
+		{
+			err:  broken.New("0 items are broken", MessageKey("items")).WithInt("count", 0),
+			lang: "fr_CA",
+			want: "0 élément est cassé",
+		},
+		{
+			err:  broken.New("3 items are broken", MessageKey("items")).WithInt("count", 3),
+			lang: "pt-BR",
+			want: "3 itens quebrados",
+		},
+		{
+			err:  broken.New("3 items are broken", MessageKey("items")).WithInt("count", 3),
+			lang: "de",
+			want: "3 items are broken",
+		},
+		{
+			err:  New("parent", Catalog(catalog)).WithInt("count", 1).New("child", MessageKey("items"), ValueInheritance(NoValues)),
+			lang: "en",
+			want: "1 item is broken",
+		},
+		{
+			err:  broken.New("namespaced", MessageKey("items"), Namespace("ns")).WithInt("count", 1),
+			lang: "en",
+			want: "1 item is broken",
+		},
+		{
+			err:  broken.New("child error"),
+			lang: "en",
+			want: "child error",
+		},
+		{
+			err:  New("no catalog", MessageKey("items")),
+			lang: "en",
+			want: "no catalog",
+		},
+	}
+
+	for i, tc := range cases {
+		got := tc.err.Localize(tc.lang)
+		if got != tc.want {
+			t.Errorf("#%d: (*Err).Localize(%#v) == %#v, want %#v", i, tc.lang, got, tc.want)
+		}
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	cases := []struct {
+		lang string
+		want []string
+	}{
+		{
+			lang: "en",
+			want: []string{"en"},
+		},
+		{
+			lang: "zh_Hant-TW",
+			want: []string{"zh-hant-tw", "zh-hant", "zh"},
+		},
+		{
+			lang: "",
+			want: nil,
+		},
+	}
+
+	for i, tc := range cases {
+		got := FallbackChain(tc.lang)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("#%d: FallbackChain(%#v) == %#v, want %#v", i, tc.lang, got, tc.want)
+		}
+	}
+}
+
+func TestErr_Localize_args(t *testing.T) {
+	catalog := NewCatalog().SetString("ja", "user_not_found", "ユーザー {0} が見つかりません ({1} {2})")
+	notFound := New("not found", Catalog(catalog))
+
+	err := notFound.Errorf("user %d is not found", 42)
+	err.WithMessageKey("user_not_found")
+
+	want := "ユーザー 42 が見つかりません ({1} {2})"
+	if got := err.Localize("ja"); got != want {
+		t.Errorf("Localize() == %#v, want %#v", got, want)
+	}
+}
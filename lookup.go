@@ -0,0 +1,92 @@
+package aerrors
+
+import "path"
+
+// Value returns the value labeled `label` and whether it is found.
+//
+// It looks up the error's values and then parents' values, the same as AllValues,
+// so the last value of the nearest error wins.
+// Values in groups are looked up by flattened labels, e.g. "db.query".
+func (e *Err) Value(label string) (v *Value, ok bool) {
+	if v, ok := lastValue(e.values, label); ok {
+		return v, true
+	}
+	for _, parent := range e.parents() {
+		if v, ok := lastValue(parent.values, label); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// lastValue returns the last value labeled `label` in `values`, including groups and values flattened from them.
+func lastValue(values []*Value, label string) (*Value, bool) {
+	for i := len(values) - 1; i >= 0; i-- {
+		if values[i].Label == label {
+			return values[i], true
+		}
+		if values[i].Kind != KindGroup {
+			continue
+		}
+		flat := values[i].Flatten()
+		for j := len(flat) - 1; j >= 0; j-- {
+			if flat[j].Label == label {
+				return flat[j], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// ValueOf returns the value labeled `label` found in `err` and its wrapped errors, and whether it is found.
+//
+// Errors are searched in the order of Walk, and each `*Err` is searched by `(*Err).Value` including its parents.
+func ValueOf(err error, label string) (v *Value, ok bool) {
+	Walk(err, func(err error) bool {
+		if e, isErr := err.(*Err); isErr {
+			v, ok = e.Value(label)
+		}
+		return !ok
+	})
+	return v, ok
+}
+
+// CollectValues returns values of `err` and its wrapped errors.
+//
+// Values are ordered by Walk, and merged by `(*Err).AllValues` for each `*Err`.
+// Groups are flattened, so values are labeled by flattened labels, e.g. "db.query".
+// Labels are de-duplicated, the value found first (that is ValueOf returns) wins.
+func CollectValues(err error) []*Value {
+	var values []*Value
+	index := map[string]int{}
+	Walk(err, func(err error) bool {
+		e, ok := err.(*Err)
+		if !ok {
+			return true
+		}
+		for _, v := range flattenValues(e.AllValues()) {
+			if _, ok := index[v.Label]; !ok {
+				index[v.Label] = len(values)
+				values = append(values, v)
+			}
+		}
+		return true
+	})
+	return values
+}
+
+// MatchValues returns values collected by CollectValues whose label matches the glob `pattern`.
+//
+// The pattern syntax is the same as path.Match, and the only possible returned error is path.ErrBadPattern.
+func MatchValues(err error, pattern string) ([]*Value, error) {
+	if _, e := path.Match(pattern, ""); e != nil {
+		return nil, e
+	}
+	var matched []*Value
+	for _, v := range CollectValues(err) {
+		if ok, _ := path.Match(pattern, v.Label); ok {
+			matched = append(matched, v)
+		}
+	}
+	return matched, nil
+}
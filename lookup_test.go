@@ -0,0 +1,80 @@
+package aerrors
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestErr_Value(t *testing.T) {
+	root := New("root").WithString("id", "root").WithString("service", "api")
+	child := root.New("child", ValueInheritance(NoValues)).WithString("id", "1").WithString("id", "2")
+
+	cases := []struct {
+		err   *Err
+		label string
+		want  string
+		ok    bool
+	}{
+		{err: child, label: "id", want: "2", ok: true},
+		{err: child, label: "service", want: "api", ok: true},
+		{err: root, label: "id", want: "root", ok: true},
+		{err: child, label: "missing"},
+		{err: New("grouped").WithGroup("db", String("table", "users")), label: "db.table", want: "users", ok: true},
+		{err: New("namespaced", Namespace("http")).WithGroup("req", String("method", "GET")), label: "http.req.method", want: "GET", ok: true},
+	}
+
+	for i, tc := range cases {
+		v, ok := tc.err.Value(tc.label)
+		if ok != tc.ok {
+			t.Errorf("#%d: Value(%q) ok = %v, want %v", i, tc.label, ok, tc.ok)
+			continue
+		}
+		if ok && v.Value != tc.want {
+			t.Errorf("#%d: Value(%q) = %q, want %q", i, tc.label, v.Value, tc.want)
+		}
+	}
+}
+
+func TestCollectValues(t *testing.T) {
+	inner := New("inner").WithString("user_id", "42").WithString("request_id", "inner")
+	outer := New("outer").WithString("request_id", "outer").WithError(fmt.Errorf("wrapped: %w", inner))
+	err := errors.Join(outer, New("other").WithGroup("db", String("table", "users")))
+
+	var got []string
+	for _, v := range CollectValues(err) {
+		got = append(got, v.String())
+	}
+	want := []string{"request_id: outer", "user_id: 42", "db.table: users"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectValues\ngot:  %#v\nwant: %#v", got, want)
+	}
+
+	if v, ok := ValueOf(err, "user_id"); !ok || v.Value != "42" {
+		t.Errorf("ValueOf(user_id) = %v, %v", v, ok)
+	}
+	if _, ok := ValueOf(errors.New("plain"), "user_id"); ok {
+		t.Error("ValueOf(plain error) ok = true")
+	}
+
+	if _, err := MatchValues(err, "["); err != path.ErrBadPattern {
+		t.Errorf("MatchValues(bad pattern) error = %v", err)
+	}
+}
+
+func ExampleMatchValues() {
+	err := New("query failed").
+		WithString("db.table", "users").
+		WithString("db.query", "SELECT 1").
+		WithString("user_id", "42")
+
+	values, _ := MatchValues(fmt.Errorf("handler: %w", err), "db.*")
+	for _, v := range values {
+		fmt.Println(v)
+	}
+	// Output:
+	// db.table: users
+	// db.query: SELECT 1
+}
@@ -0,0 +1,184 @@
+// Package metrics counts errors created by aerrors.
+//
+// Collector is registered to aerrors.Config as a hook.
+//
+//	collector := metrics.NewCollector()
+//	aerrors.DefaultConfig.WithHook(collector.Hook())
+//	http.Handle("/metrics", collector.Handler())
+//
+// Errors created by package-level sentinel errors are counted even if the sentinels are created before the registration.
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+	"github.com/kamiaka/aerrors/internal/stack"
+)
+
+// Key of counts.
+type Key struct {
+	// Root is the message template of the root parent error,
+	// so errors formatted with different arguments are counted together and the cardinality is bounded.
+	Root string
+	// Priority of the error.
+	Priority aerrors.ErrorPriority
+	// Caller is the location where the error is created.
+	//   e.g., pkg.Func:path/to/file.go:line
+	Caller string
+}
+
+// Count of errors for Key.
+type Count struct {
+	Key   Key
+	Count uint64
+}
+
+// Snapshot of counts.
+type Snapshot struct {
+	// Time when the snapshot is taken.
+	Time time.Time
+	// Start is the time when the collector is created or reset.
+	Start time.Time
+	// Counts sorted by key.
+	Counts []Count
+}
+
+// Total returns the sum of counts.
+func (s *Snapshot) Total() uint64 {
+	var total uint64
+	for _, c := range s.Counts {
+		total += c.Count
+	}
+	return total
+}
+
+// Rates returns errors per second for each key since the snapshot `prev`.
+//
+// If `prev` is nil or taken before the start, e.g. the collector is reset after `prev`, rates are calculated since the start.
+// The rate of the key whose count is less than `prev` is also calculated since the start.
+func (s *Snapshot) Rates(prev *Snapshot) map[Key]float64 {
+	prevCounts := map[Key]uint64{}
+	if prev != nil && !prev.Time.Before(s.Start) {
+		for _, c := range prev.Counts {
+			prevCounts[c.Key] = c.Count
+		}
+	} else {
+		prev = nil
+	}
+
+	rates := make(map[Key]float64, len(s.Counts))
+	for _, c := range s.Counts {
+		since, count := s.Start, c.Count
+		if n, ok := prevCounts[c.Key]; prev != nil && (!ok || n <= c.Count) {
+			since, count = prev.Time, c.Count-n
+		}
+		elapsed := s.Time.Sub(since).Seconds()
+		if elapsed <= 0 {
+			rates[c.Key] = 0
+			continue
+		}
+		rates[c.Key] = float64(count) / elapsed
+	}
+	return rates
+}
+
+// Collector counts errors by Key.
+type Collector struct {
+	mu     sync.Mutex
+	counts map[Key]uint64
+	start  time.Time
+	now    func() time.Time
+}
+
+// NewCollector returns new Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		counts: map[Key]uint64{},
+		start:  time.Now(),
+		now:    time.Now,
+	}
+}
+
+// WithClock sets the func returns current time and returns receiver.
+// It also resets the start time.
+func (c *Collector) WithClock(now func() time.Time) *Collector {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+	c.start = now()
+	return c
+}
+
+// Hook returns aerrors.Hook that observes created errors.
+func (c *Collector) Hook() aerrors.Hook {
+	return c.Observe
+}
+
+// Observe counts the error `e`.
+func (c *Collector) Observe(e *aerrors.Err) {
+	key := KeyOf(e)
+
+	c.mu.Lock()
+	c.counts[key]++
+	c.mu.Unlock()
+}
+
+// Snapshot returns current counts.
+func (c *Collector) Snapshot() *Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s := &Snapshot{
+		Time:   c.now(),
+		Start:  c.start,
+		Counts: make([]Count, 0, len(c.counts)),
+	}
+	for k, n := range c.counts {
+		s.Counts = append(s.Counts, Count{Key: k, Count: n})
+	}
+	sort.Slice(s.Counts, func(i, j int) bool {
+		return lessKey(s.Counts[i].Key, s.Counts[j].Key)
+	})
+	return s
+}
+
+// Reset counts and the start time.
+func (c *Collector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts = map[Key]uint64{}
+	c.start = c.now()
+}
+
+// KeyOf returns Key of the error `e`.
+func KeyOf(e *aerrors.Err) Key {
+	root := e
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+
+	var caller string
+	if frame, _ := e.Callers().Next(); frame.PC != 0 {
+		caller = stack.SimpleFunc(frame.Function) + ":" + frame.File + ":" + strconv.Itoa(frame.Line)
+	}
+
+	return Key{
+		Root:     root.Template(),
+		Priority: e.Priority(),
+		Caller:   caller,
+	}
+}
+
+func lessKey(a, b Key) bool {
+	if a.Root != b.Root {
+		return a.Root < b.Root
+	}
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	return a.Caller < b.Caller
+}
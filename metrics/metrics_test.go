@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+)
+
+func TestCollector(t *testing.T) {
+	now := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	collector := NewCollector().WithClock(func() time.Time { return now })
+	conf := aerrors.DefaultConfig.Clone().WithHook(collector.Hook())
+
+	appError := conf.Error("app error")
+	for i := 0; i < 3; i++ {
+		appError.New("oops")
+	}
+	appError.New("warning", aerrors.Priority(aerrors.Warning))
+	for i := 0; i < 2; i++ {
+		conf.Errorf("user %d is not found", i)
+	}
+	aerrors.New("not observed")
+
+	now = now.Add(2 * time.Second)
+	snapshot := collector.Snapshot()
+
+	if got := snapshot.Total(); got != 7 {
+		t.Errorf("Total() == %d, want 7", got)
+	}
+
+	want := map[string]uint64{
+		"app error/Error":            4,
+		"app error/Warning":          1,
+		"user %d is not found/Error": 2,
+	}
+	got := map[string]uint64{}
+	for _, c := range snapshot.Counts {
+		got[c.Key.Root+"/"+c.Key.Priority.String()] += c.Count
+		if !regexp.MustCompile(`^metrics\.TestCollector:.+/metrics_test\.go:\d+$`).MatchString(c.Key.Caller) {
+			t.Errorf("Caller == %#v, want metrics_test.go location", c.Key.Caller)
+		}
+	}
+	for k, n := range want {
+		if got[k] != n {
+			t.Errorf("count of %s == %d, want %d", k, got[k], n)
+		}
+	}
+
+	for key, rate := range snapshot.Rates(nil) {
+		if key.Priority == aerrors.Warning && rate != 0.5 {
+			t.Errorf("rate of %v == %v, want 0.5", key, rate)
+		}
+	}
+
+	appError.New("warning", aerrors.Priority(aerrors.Warning))
+	now = now.Add(4 * time.Second)
+	var warningRate float64
+	for key, rate := range collector.Snapshot().Rates(snapshot) {
+		if key.Priority == aerrors.Warning {
+			warningRate += rate
+		}
+	}
+	if warningRate != 0.25 {
+		t.Errorf("rate of Warning since snapshot == %v, want 0.25", warningRate)
+	}
+
+	beforeReset := collector.Snapshot()
+	now = now.Add(time.Second)
+	collector.Reset()
+	if got := collector.Snapshot().Total(); got != 0 {
+		t.Errorf("Total() after Reset == %d, want 0", got)
+	}
+
+	appError.New("oops")
+	now = now.Add(2 * time.Second)
+	for key, rate := range collector.Snapshot().Rates(beforeReset) {
+		if rate != 0.5 {
+			t.Errorf("rate of %v since reset == %v, want 0.5", key, rate)
+		}
+	}
+
+	// the snapshot taken when reset has greater counts.
+	current := collector.Snapshot()
+	atReset := &Snapshot{Time: current.Start, Counts: []Count{{Key: current.Counts[0].Key, Count: 10}}}
+	for key, rate := range current.Rates(atReset) {
+		if rate != 0.5 {
+			t.Errorf("rate of %v since the snapshot has greater counts == %v, want 0.5", key, rate)
+		}
+	}
+}
+
+// sentinelConf and errNotFound are created at init time, before the collector is registered.
+var (
+	sentinelConf = aerrors.DefaultConfig.Clone()
+	errNotFound  = sentinelConf.Error("not found")
+)
+
+func TestCollector_sentinel(t *testing.T) {
+	collector := NewCollector()
+	sentinelConf.WithHook(collector.Hook())
+
+	errNotFound.New("user 1")
+	errNotFound.Errorf("user %d", 2)
+	errNotFound.Wrap(errors.New("origin"))
+
+	if got := collector.Snapshot().Total(); got != 3 {
+		t.Errorf("Total() == %d, want 3", got)
+	}
+}
+
+func TestCollector_Handler(t *testing.T) {
+	collector := NewCollector()
+	conf := aerrors.DefaultConfig.Clone().WithHook(collector.Hook())
+	conf.Error("app \"error\"\n")
+
+	rec := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+
+	want := regexp.MustCompile(`^# HELP aerrors_errors_total Number of errors created.
+# TYPE aerrors_errors_total counter
+aerrors_errors_total\{root="app \\"error\\"\\n",priority="Error",caller="metrics\.TestCollector_Handler:[^"]+:\d+"\} 1
+$`)
+	if !want.Match(body) {
+		t.Errorf("body:\n%s", body)
+	}
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MetricName is the name of the counter in Prometheus text exposition format.
+var MetricName = "aerrors_errors_total"
+
+// WritePrometheus writes the snapshot in Prometheus text exposition format.
+func (s *Snapshot) WritePrometheus(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	b.WriteString("# HELP " + MetricName + " Number of errors created.\n")
+	b.WriteString("# TYPE " + MetricName + " counter\n")
+	for _, c := range s.Counts {
+		b.WriteString(MetricName)
+		b.WriteString(`{root="` + escapeLabel(c.Key.Root))
+		b.WriteString(`",priority="` + escapeLabel(c.Key.Priority.String()))
+		b.WriteString(`",caller="` + escapeLabel(c.Key.Caller))
+		b.WriteString(`"} `)
+		b.WriteString(strconv.FormatUint(c.Count, 10))
+		b.WriteByte('\n')
+	}
+	return b.Flush()
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// Handler returns http.Handler that renders counts in Prometheus text exposition format.
+// It responds 500 Internal Server Error if the counts cannot be rendered.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var buf bytes.Buffer
+		if err := c.Snapshot().WritePrometheus(&buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write(buf.Bytes())
+	})
+}
@@ -1,5 +1,7 @@
 package aerrors
 
+import "time"
+
 // Option for create error `*Err`.
 type Option func(*Config) *Config
 
@@ -24,6 +26,107 @@ func CallerSkip(n int) Option {
 	}
 }
 
+// PublicMessage option configures the message that is safe to show to end users.
+func PublicMessage(msg string) Option {
+	return func(c *Config) *Config {
+		return c.WithPublicMessage(msg)
+	}
+}
+
+// MessageKey option configures the key of localized message.
+// The key is not inherited by children.
+func MessageKey(key string) Option {
+	return func(c *Config) *Config {
+		return c.WithMessageKey(key)
+	}
+}
+
+// Catalog option configures catalog for localized messages.
+func Catalog(catalog MessageCatalog) Option {
+	return func(c *Config) *Config {
+		return c.WithCatalog(catalog)
+	}
+}
+
+// Hooks option appends hooks called when new error is created.
+func Hooks(hooks ...Hook) Option {
+	return func(c *Config) *Config {
+		return c.WithHook(hooks...)
+	}
+}
+
+// Fingerprint option configures components of fingerprint.
+func Fingerprint(components FingerprintComponent) Option {
+	return func(c *Config) *Config {
+		return c.WithFingerprint(components)
+	}
+}
+
+// CaptureArgs option configures whether arguments of Errorf are captured as Values.
+func CaptureArgs(b bool) Option {
+	return func(c *Config) *Config {
+		return c.WithCaptureArgs(b)
+	}
+}
+
+// CallerPath option configures PathRewriter for callers and stack traces.
+//
+//	aerrors.New("oops", aerrors.CallerPath(aerrors.ModuleRelativePath))
+func CallerPath(r PathRewriter) Option {
+	return func(c *Config) *Config {
+		return c.WithCallerPath(r)
+	}
+}
+
+// CallerLine option configures whether line numbers of callers and stack traces are rendered.
+func CallerLine(b bool) Option {
+	return func(c *Config) *Config {
+		return c.WithCallerLine(b)
+	}
+}
+
+// ValueInheritance option configures the policy of values inherited from the parent.
+func ValueInheritance(p ValueInheritancePolicy) Option {
+	return func(c *Config) *Config {
+		return c.WithValueInheritance(p)
+	}
+}
+
+// Namespace option configures the namespace prefixed to labels of values added to the error and its children.
+func Namespace(ns string) Option {
+	return func(c *Config) *Config {
+		return c.WithNamespace(ns)
+	}
+}
+
+// FormatBytes option configures the format rendering bytes Values added to the error and its children.
+func FormatBytes(f BytesFormat) Option {
+	return func(c *Config) *Config {
+		return c.WithBytesFormat(f)
+	}
+}
+
+// Limit option configures the size budget of the error output.
+func Limit(l Limits) Option {
+	return func(c *Config) *Config {
+		return c.WithLimits(l)
+	}
+}
+
+// Clock option configures the func returns the time when the error is created.
+func Clock(now func() time.Time) Option {
+	return func(c *Config) *Config {
+		return c.WithClock(now)
+	}
+}
+
+// GenerateID option configures the generator of the error ID.
+func GenerateID(g IDGenerator) Option {
+	return func(c *Config) *Config {
+		return c.WithIDGenerator(g)
+	}
+}
+
 // Formatter option configures error formatter.
 func Formatter(f ErrorFormatter) Option {
 	return func(c *Config) *Config {
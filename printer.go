@@ -0,0 +1,252 @@
+// The implementation of formatError, state and printer is derived from golang.org/x/xerrors.
+//
+// Copyright (c) 2019 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-xerrors file.
+
+package aerrors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+)
+
+// Printer prints error messages.
+//
+// It has the same methods as golang.org/x/xerrors.Printer.
+type Printer interface {
+	// Print appends args to the message output.
+	Print(args ...interface{})
+
+	// Printf writes a formatted string.
+	Printf(format string, args ...interface{})
+
+	// Detail reports whether error detail is requested.
+	// After the first call to Detail, all text written to the Printer
+	// is formatted as additional detail, or ignored when
+	// detail has not been requested.
+	// If Detail returns false, the caller can avoid printing the detail at all.
+	Detail() bool
+}
+
+// formatter is implemented by errors that print themselves to Printer.
+type formatter interface {
+	FormatError(p Printer) (next error)
+}
+
+// AdaptFormatter adapts the func formats error with other printer interface,
+// e.g. func(xerrors.Printer, *Err) error, to ErrorFormatter.
+//
+// The printer interface `P` must be satisfied by Printer.
+func AdaptFormatter[P interface {
+	Print(args ...interface{})
+	Printf(format string, args ...interface{})
+	Detail() bool
+}](f func(P, *Err) (next error)) ErrorFormatter {
+	return func(p Printer, e *Err) (next error) {
+		return f(p.(P), e)
+	}
+}
+
+// formatError formats the error `err` according to `s` and `verb`.
+func formatError(err error, s fmt.State, verb rune) {
+	var (
+		sep    = " " // separator before next error
+		limits Limits
+		direct = true
+	)
+	if e, ok := err.(*Err); ok {
+		limits = e.limits
+	}
+	p := &state{State: s, visited: limits.visitor()}
+
+	switch verb {
+	// Note that this switch must match the preference order
+	// for ordinary string printing (%#v before %+v, and so on).
+
+	case 'v':
+		if s.Flag('#') {
+			if stringer, ok := err.(fmt.GoStringer); ok {
+				io.WriteString(&p.buf, stringer.GoString())
+				goto exit
+			}
+			// proceed as if it were %v
+		} else if s.Flag('+') {
+			p.printDetail = true
+			sep = "\n  - "
+		}
+	case 's':
+	case 'q', 'x', 'X':
+		// Use an intermediate buffer in the rare cases that precision,
+		// truncation, or one of the alternative verbs (q, x, and X) are
+		// specified.
+		direct = false
+
+	default:
+		p.buf.WriteString("%!")
+		p.buf.WriteRune(verb)
+		p.buf.WriteByte('(')
+		p.buf.WriteString(reflect.TypeOf(err).String())
+		p.buf.WriteByte(')')
+		io.Copy(s, &p.buf)
+		return
+	}
+
+	p.printChain(err, sep)
+	if limits.MaxBytes > 0 && p.buf.Len() > limits.MaxBytes {
+		truncated := truncate(p.buf.String(), limits.MaxBytes)
+		p.buf.Reset()
+		p.buf.WriteString(truncated)
+	}
+
+exit:
+	width, okW := s.Width()
+	prec, okP := s.Precision()
+
+	if !direct || (okW && width > 0) || okP {
+		// Construct format string from State s.
+		format := []byte{'%'}
+		if s.Flag('-') {
+			format = append(format, '-')
+		}
+		if s.Flag('+') {
+			format = append(format, '+')
+		}
+		if s.Flag(' ') {
+			format = append(format, ' ')
+		}
+		if okW {
+			format = strconv.AppendInt(format, int64(width), 10)
+		}
+		if okP {
+			format = append(format, '.')
+			format = strconv.AppendInt(format, int64(prec), 10)
+		}
+		format = append(format, string(verb)...)
+		fmt.Fprintf(s, string(format), p.buf.String())
+	} else {
+		io.Copy(s, &p.buf)
+	}
+}
+
+// printChain prints the error and its wrapped errors.
+// It prints CycleMarker or DepthMarker instead of errors already printed or too deep.
+func (p *state) printChain(err error, sep string) {
+loop:
+	for {
+		if marker, ok := p.visited.visit(err); !ok {
+			io.WriteString(&p.buf, marker)
+			break
+		}
+		switch v := err.(type) {
+		case formatter:
+			err = v.FormatError((*printer)(p))
+		case fmt.Formatter:
+			v.Format(p, 'v')
+			break loop
+		default:
+			io.WriteString(&p.buf, v.Error())
+			break loop
+		}
+		if err == nil {
+			break
+		}
+		if p.needColon || !p.printDetail {
+			p.buf.WriteByte(':')
+			p.needColon = false
+		}
+		p.buf.WriteString(sep)
+		p.inDetail = false
+		p.needNewline = false
+	}
+}
+
+// sprintDetail returns the error printed as `%+v` with the visitor of branch.
+func sprintDetail(err error, v *visitor) string {
+	p := &state{State: detailState{}, printDetail: true, visited: v}
+	p.printChain(err, "\n  - ")
+	return p.buf.String()
+}
+
+// detailState is fmt.State for `%+v`. Its output is discarded.
+type detailState struct{}
+
+func (detailState) Write(b []byte) (int, error) { return len(b), nil }
+func (detailState) Width() (int, bool)          { return 0, false }
+func (detailState) Precision() (int, bool)      { return 0, false }
+func (detailState) Flag(c int) bool             { return c == '+' }
+
+var detailSep = []byte("\n    ")
+
+// state tracks error printing state. It implements fmt.State.
+type state struct {
+	fmt.State
+	buf bytes.Buffer
+
+	printDetail bool
+	inDetail    bool
+	needColon   bool
+	needNewline bool
+
+	visited *visitor
+}
+
+func (s *state) Write(b []byte) (n int, err error) {
+	if s.printDetail {
+		if len(b) == 0 {
+			return 0, nil
+		}
+		if s.inDetail && s.needColon {
+			s.needNewline = true
+			if b[0] == '\n' {
+				b = b[1:]
+			}
+		}
+		k := 0
+		for i, c := range b {
+			if s.needNewline {
+				if s.inDetail && s.needColon {
+					s.buf.WriteByte(':')
+					s.needColon = false
+				}
+				s.buf.Write(detailSep)
+				s.needNewline = false
+			}
+			if c == '\n' {
+				s.buf.Write(b[k:i])
+				k = i + 1
+				s.needNewline = true
+			}
+		}
+		s.buf.Write(b[k:])
+		if !s.inDetail {
+			s.needColon = true
+		}
+	} else if !s.inDetail {
+		s.buf.Write(b)
+	}
+	return len(b), nil
+}
+
+// printer wraps a state to implement Printer.
+type printer state
+
+func (s *printer) Print(args ...interface{}) {
+	if !s.inDetail || s.printDetail {
+		fmt.Fprint((*state)(s), args...)
+	}
+}
+
+func (s *printer) Printf(format string, args ...interface{}) {
+	if !s.inDetail || s.printDetail {
+		fmt.Fprintf((*state)(s), format, args...)
+	}
+}
+
+func (s *printer) Detail() bool {
+	s.inDetail = true
+	return s.printDetail
+}
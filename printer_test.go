@@ -0,0 +1,81 @@
+package aerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// xerrorsPrinter has the same methods as golang.org/x/xerrors.Printer.
+type xerrorsPrinter interface {
+	Print(args ...interface{})
+	Printf(format string, args ...interface{})
+	Detail() bool
+}
+
+func TestErr_Format(t *testing.T) {
+	conf := DefaultConfig.Clone().WithCallerDepth(0).WithClock(testClock).WithIDGenerator(testIDGenerator)
+	err := conf.Errorf("error: %w", errors.New("oops")).WithString("label", "value")
+
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "%v",
+			want:   "error: oops",
+		},
+		{
+			format: "%s",
+			want:   "error: oops",
+		},
+		{
+			format: "%q",
+			want:   `"error: oops"`,
+		},
+		{
+			format: "%x",
+			want:   "6572726f723a206f6f7073",
+		},
+		{
+			format: "%15v|",
+			want:   "    error: oops|",
+		},
+		{
+			format: "%-15s|",
+			want:   "error: oops    |",
+		},
+		{
+			format: "%.5s",
+			want:   "error",
+		},
+		{
+			format: "%+v",
+			want:   "error: oops:\n    priority: Error\n    callers: \n    time: 2001-02-03T04:05:06.000000007Z\n    error_id: 01ARZ3NDEKTSV4RRFFQ69G5FAV\n    label: value\n  - oops",
+		},
+		{
+			format: "%d",
+			want:   "%!d(*aerrors.Err)",
+		},
+	}
+
+	for i, tc := range cases {
+		got := fmt.Sprintf(tc.format, err)
+		if got != tc.want {
+			t.Errorf("#%d: fmt.Sprintf(%#v, err) == %#v, want %#v", i, tc.format, got, tc.want)
+		}
+	}
+}
+
+func ExampleAdaptFormatter() {
+	formatter := func(p xerrorsPrinter, e *Err) error {
+		p.Printf("[%v] %s", e.Priority(), e.Error())
+		return nil
+	}
+
+	err := New("oops", Formatter(AdaptFormatter(formatter)))
+
+	fmt.Printf("%v\n", err)
+	// Output:
+	// [Error] oops
+}
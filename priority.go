@@ -25,7 +25,11 @@ const (
 //   aerrors.PriorityNames = map[aerrors.ErrorPriority]string{
 //      Foo: "foo error",
 //   }
-var PriorityNames = map[ErrorPriority]string{
+var PriorityNames = defaultPriorityNames()
+
+// priorityConstNames are names of built in priority constants.
+// They are the default PriorityNames, and used by GoString even if PriorityNames is overwritten.
+var priorityConstNames = [...]string{
 	Emergency: "Emergency",
 	Alert:     "Alert",
 	Critical:  "Critical",
@@ -36,11 +40,45 @@ var PriorityNames = map[ErrorPriority]string{
 	Debug:     "Debug",
 }
 
+func defaultPriorityNames() map[ErrorPriority]string {
+	names := make(map[ErrorPriority]string, len(priorityConstNames))
+	for p, name := range priorityConstNames {
+		names[ErrorPriority(p)] = name
+	}
+	return names
+}
+
+// PublicMessages for (*Err).PublicMessage
+// when neither the error nor its parents have public message.
+//
+// It can overwrite for user defined priority.
+var PublicMessages = map[ErrorPriority]string{
+	Emergency: "A serious error has occurred. Please try again later.",
+	Alert:     "A serious error has occurred. Please try again later.",
+	Critical:  "A serious error has occurred. Please try again later.",
+	Error:     "An error has occurred. Please try again later.",
+	Warning:   "The request could not be completed.",
+	Notice:    "The request could not be completed.",
+	Info:      "The request could not be completed.",
+	Debug:     "The request could not be completed.",
+}
+
+// DefaultPublicMessage is used when PublicMessages has no message for the priority.
+var DefaultPublicMessage = "An error has occurred."
+
 // HigherThan reports whether the priority s is higher priority than t.
 func (p ErrorPriority) HigherThan(q ErrorPriority) bool {
 	return p < q
 }
 
+// GoString returns Go syntax of the priority, e.g., "aerrors.Error".
+func (p ErrorPriority) GoString() string {
+	if p >= 0 && int(p) < len(priorityConstNames) {
+		return "aerrors." + priorityConstNames[p]
+	}
+	return fmt.Sprintf("aerrors.ErrorPriority(%d)", int(p))
+}
+
 func (p ErrorPriority) String() string {
 	if name, ok := PriorityNames[p]; ok {
 		return name
@@ -59,3 +59,34 @@ func TestErrorPriority_String(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorPriority_GoString(t *testing.T) {
+	cases := []struct {
+		priority ErrorPriority
+		want     string
+	}{
+		{
+			priority: Warning,
+			want:     "aerrors.Warning",
+		},
+		{
+			priority: ErrorPriority(999),
+			want:     "aerrors.ErrorPriority(999)",
+		},
+		{
+			priority: ErrorPriority(-1),
+			want:     "aerrors.ErrorPriority(-1)",
+		},
+	}
+
+	tmp := PriorityNames
+	PriorityNames = map[ErrorPriority]string{Warning: "warn"}
+	defer func() { PriorityNames = tmp }()
+
+	for i, tc := range cases {
+		got := tc.priority.GoString()
+		if got != tc.want {
+			t.Errorf("#%d: (ErrorPriority(%d)).GoString() == %#v, want %#v", i, tc.priority, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"time"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// Config for Dispatcher.
+type Config struct {
+	threshold     aerrors.ErrorPriority
+	queueSize     int
+	batchSize     int
+	flushInterval time.Duration
+	handleError   func(error)
+}
+
+// DefaultConfig is used when NewDispatcher is called with nil config.
+var DefaultConfig = &Config{
+	threshold:     aerrors.Error,
+	queueSize:     1024,
+	batchSize:     64,
+	flushInterval: 5 * time.Second,
+	handleError:   func(error) {},
+}
+
+// Threshold returns the lowest priority of reported errors.
+func (c *Config) Threshold() aerrors.ErrorPriority {
+	return c.threshold
+}
+
+// WithThreshold sets the lowest priority of reported errors and return receiver.
+func (c *Config) WithThreshold(p aerrors.ErrorPriority) *Config {
+	c.threshold = p
+	return c
+}
+
+// QueueSize returns the maximum number of queued errors.
+func (c *Config) QueueSize() int {
+	return c.queueSize
+}
+
+// WithQueueSize sets the maximum number of queued errors and return receiver.
+// Errors reported when the queue is full are dropped.
+func (c *Config) WithQueueSize(n int) *Config {
+	c.queueSize = n
+	return c
+}
+
+// BatchSize returns the maximum number of errors delivered at once.
+func (c *Config) BatchSize() int {
+	return c.batchSize
+}
+
+// WithBatchSize sets the maximum number of errors delivered at once and return receiver.
+func (c *Config) WithBatchSize(n int) *Config {
+	c.batchSize = n
+	return c
+}
+
+// FlushInterval returns the interval of delivery.
+func (c *Config) FlushInterval() time.Duration {
+	return c.flushInterval
+}
+
+// WithFlushInterval sets the interval of delivery and return receiver.
+func (c *Config) WithFlushInterval(d time.Duration) *Config {
+	c.flushInterval = d
+	return c
+}
+
+// WithErrorHandler sets the func called when Reporter fails and return receiver.
+func (c *Config) WithErrorHandler(f func(error)) *Config {
+	c.handleError = f
+	return c
+}
+
+// Clone *Config.
+func (c *Config) Clone() *Config {
+	copy := *c
+	return &copy
+}
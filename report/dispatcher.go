@@ -0,0 +1,171 @@
+package report
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// Dispatcher delivers errors to Reporter in batches asynchronously.
+type Dispatcher struct {
+	reporter Reporter
+	conf     *Config
+
+	mu      sync.RWMutex
+	closed  bool
+	queue   chan *aerrors.Err
+	flushes chan chan struct{}
+	closing chan struct{}
+	done    chan struct{}
+	dropped uint64
+}
+
+// NewDispatcher returns new Dispatcher and starts delivery.
+// If `conf` is nil, DefaultConfig is used.
+func NewDispatcher(r Reporter, conf *Config) *Dispatcher {
+	if conf == nil {
+		conf = DefaultConfig
+	}
+	conf = conf.Clone()
+	if conf.batchSize <= 0 {
+		conf.batchSize = 1
+	}
+	if conf.handleError == nil {
+		conf.handleError = func(error) {}
+	}
+
+	d := &Dispatcher{
+		reporter: r,
+		conf:     conf,
+		queue:    make(chan *aerrors.Err, conf.queueSize),
+		flushes:  make(chan chan struct{}),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go d.run()
+	return d
+}
+
+// Report queues the error `e` and reports whether it is queued.
+//
+// Errors lower priority than the threshold, errors reported after Close
+// and errors reported when the queue is full are not queued.
+func (d *Dispatcher) Report(e *aerrors.Err) bool {
+	if e == nil || d.conf.threshold.HigherThan(e.Priority()) {
+		return false
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return false
+	}
+	select {
+	case d.queue <- e:
+		return true
+	default:
+		atomic.AddUint64(&d.dropped, 1)
+		return false
+	}
+}
+
+// Dropped returns the number of errors dropped because the queue is full.
+func (d *Dispatcher) Dropped() uint64 {
+	return atomic.LoadUint64(&d.dropped)
+}
+
+// Flush delivers queued errors and waits until delivered or `ctx` is done.
+func (d *Dispatcher) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	select {
+	case d.flushes <- flushed:
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting errors, delivers queued errors and waits until delivered or `ctx` is done.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.closing)
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) run() {
+	defer close(d.done)
+
+	var tick <-chan time.Time
+	if d.conf.flushInterval > 0 {
+		ticker := time.NewTicker(d.conf.flushInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	batch := make([]*aerrors.Err, 0, d.conf.batchSize)
+	for {
+		select {
+		case e := <-d.queue:
+			batch = append(batch, e)
+			if len(batch) >= d.conf.batchSize {
+				batch = d.deliver(batch)
+			}
+		case <-tick:
+			batch = d.deliver(batch)
+		case flushed := <-d.flushes:
+			batch = d.deliver(d.drain(batch))
+			close(flushed)
+		case <-d.closing:
+			d.deliver(d.drain(batch))
+			return
+		}
+	}
+}
+
+// drain appends queued errors to the batch, delivering full batches.
+func (d *Dispatcher) drain(batch []*aerrors.Err) []*aerrors.Err {
+	for {
+		select {
+		case e := <-d.queue:
+			batch = append(batch, e)
+			if len(batch) >= d.conf.batchSize {
+				batch = d.deliver(batch)
+			}
+		default:
+			return batch
+		}
+	}
+}
+
+// deliver the batch and returns emptied batch.
+func (d *Dispatcher) deliver(batch []*aerrors.Err) []*aerrors.Err {
+	if len(batch) == 0 {
+		return batch
+	}
+	errs := make([]*aerrors.Err, len(batch))
+	copy(errs, batch)
+	if err := d.reporter.Report(context.Background(), errs); err != nil {
+		d.conf.handleError(err)
+	}
+	return batch[:0]
+}
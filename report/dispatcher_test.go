@@ -0,0 +1,99 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+)
+
+func TestDispatcher(t *testing.T) {
+	reporter := NewMemoryReporter()
+	d := NewDispatcher(reporter, DefaultConfig.Clone().WithBatchSize(2).WithFlushInterval(0))
+
+	reported := []bool{
+		d.Report(aerrors.New("critical", aerrors.Priority(aerrors.Critical))),
+		d.Report(aerrors.New("error")),
+		d.Report(aerrors.New("warning", aerrors.Priority(aerrors.Warning))),
+		d.Report(aerrors.New("last error")),
+	}
+	want := []bool{true, true, false, true}
+	for i := range want {
+		if reported[i] != want[i] {
+			t.Errorf("#%d: Report() == %v, want %v", i, reported[i], want[i])
+		}
+	}
+
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if d.Report(aerrors.New("after close")) {
+		t.Errorf("Report() after Close == true, want false")
+	}
+
+	var msgs []string
+	for _, e := range reporter.Errors() {
+		msgs = append(msgs, e.Error())
+	}
+	wantMsgs := []string{"critical", "error", "last error"}
+	if len(msgs) != len(wantMsgs) {
+		t.Fatalf("reported: %v, want %v", msgs, wantMsgs)
+	}
+	for i := range wantMsgs {
+		if msgs[i] != wantMsgs[i] {
+			t.Errorf("#%d: reported %#v, want %#v", i, msgs[i], wantMsgs[i])
+		}
+	}
+	if got := reporter.Batches(); got != 2 {
+		t.Errorf("Batches() == %d, want 2", got)
+	}
+}
+
+func TestDispatcher_Flush(t *testing.T) {
+	reporter := NewMemoryReporter()
+	d := NewDispatcher(reporter, DefaultConfig.Clone().WithFlushInterval(time.Hour))
+	defer d.Close(context.Background())
+
+	d.Report(aerrors.New("error"))
+	if err := d.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(reporter.Errors()); got != 1 {
+		t.Errorf("len(Errors()) == %d, want 1", got)
+	}
+}
+
+func TestDispatcher_queueFull(t *testing.T) {
+	block := make(chan struct{})
+	reporting := make(chan struct{}, 1)
+	var handled error
+	reporter := ReporterFunc(func(ctx context.Context, errs []*aerrors.Err) error {
+		reporting <- struct{}{}
+		<-block
+		return errors.New("unavailable")
+	})
+	d := NewDispatcher(reporter, DefaultConfig.Clone().
+		WithQueueSize(1).
+		WithBatchSize(1).
+		WithErrorHandler(func(err error) { handled = err }))
+
+	d.Report(aerrors.New("delivering"))
+	<-reporting
+	d.Report(aerrors.New("queued"))
+	if d.Report(aerrors.New("dropped")) {
+		t.Errorf("Report() to full queue == true, want false")
+	}
+	if got := d.Dropped(); got != 1 {
+		t.Errorf("Dropped() == %d, want 1", got)
+	}
+
+	close(block)
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if handled == nil {
+		t.Errorf("error handler is not called")
+	}
+}
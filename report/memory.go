@@ -0,0 +1,53 @@
+package report
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// MemoryReporter keeps reported errors in memory. It is useful for tests.
+type MemoryReporter struct {
+	mu      sync.Mutex
+	errs    []*aerrors.Err
+	batches int
+}
+
+// NewMemoryReporter returns new MemoryReporter.
+func NewMemoryReporter() *MemoryReporter {
+	return &MemoryReporter{}
+}
+
+// Report implements Reporter.
+func (r *MemoryReporter) Report(ctx context.Context, errs []*aerrors.Err) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, errs...)
+	r.batches++
+	return nil
+}
+
+// Errors returns reported errors.
+func (r *MemoryReporter) Errors() []*aerrors.Err {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	errs := make([]*aerrors.Err, len(r.errs))
+	copy(errs, r.errs)
+	return errs
+}
+
+// Batches returns the number of Report calls.
+func (r *MemoryReporter) Batches() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.batches
+}
+
+// Reset reported errors.
+func (r *MemoryReporter) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = nil
+	r.batches = 0
+}
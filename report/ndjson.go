@@ -0,0 +1,53 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// NDJSONReporter writes errors to io.Writer as newline delimited JSON.
+type NDJSONReporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewNDJSONReporter returns Reporter writes errors to `w`.
+func NewNDJSONReporter(w io.Writer) *NDJSONReporter {
+	return &NDJSONReporter{w: w}
+}
+
+// OpenFile opens the file `name` for appending and returns Reporter writes errors to it.
+func OpenFile(name string) (*NDJSONReporter, error) {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return NewNDJSONReporter(f), nil
+}
+
+// Report implements Reporter.
+func (r *NDJSONReporter) Report(ctx context.Context, errs []*aerrors.Err) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	enc := json.NewEncoder(r.w)
+	for _, e := range errs {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the writer if it implements io.Closer.
+func (r *NDJSONReporter) Close() error {
+	if c, ok := r.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kamiaka/aerrors"
+)
+
+func TestNDJSONReporter(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewNDJSONReporter(&buf)
+
+	errs := []*aerrors.Err{
+		aerrors.New("first").WithInt("id", 1),
+		aerrors.New("second", aerrors.Priority(aerrors.Critical)),
+	}
+	if err := r.Report(context.Background(), errs); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines: %#v", lines)
+	}
+	for i, line := range lines {
+		var got struct {
+			Message  string `json:"message"`
+			Priority string `json:"priority"`
+		}
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if got.Message != errs[i].Error() || got.Priority != errs[i].Priority().String() {
+			t.Errorf("#%d: got %+v", i, got)
+		}
+	}
+}
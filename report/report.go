@@ -0,0 +1,28 @@
+// Package report delivers errors to error trackers.
+//
+// Dispatcher queues errors and delivers them to Reporter in batches asynchronously.
+//
+//	d := report.NewDispatcher(reporter, nil)
+//	defer d.Close(context.Background())
+//
+//	d.Report(err)
+package report
+
+import (
+	"context"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// Reporter delivers errors.
+type Reporter interface {
+	Report(ctx context.Context, errs []*aerrors.Err) error
+}
+
+// ReporterFunc is func implements Reporter.
+type ReporterFunc func(ctx context.Context, errs []*aerrors.Err) error
+
+// Report implements Reporter.
+func (f ReporterFunc) Report(ctx context.Context, errs []*aerrors.Err) error {
+	return f(ctx, errs)
+}
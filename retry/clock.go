@@ -0,0 +1,23 @@
+package retry
+
+import "time"
+
+// Clock provides the current time and timers for Do.
+// It can be replaced for deterministic tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is Clock using package `time`.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,132 @@
+package retry
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Policy for Do.
+type Policy struct {
+	maxAttempts     int
+	initialInterval time.Duration
+	maxInterval     time.Duration
+	multiplier      float64
+	jitter          float64
+	retryable       func(error) bool
+	clock           Clock
+	random          func() float64
+}
+
+// DefaultPolicy is used when Do is called with nil policy.
+var DefaultPolicy = &Policy{
+	maxAttempts:     3,
+	initialInterval: 100 * time.Millisecond,
+	maxInterval:     10 * time.Second,
+	multiplier:      2,
+	jitter:          0.2,
+	retryable:       IsRetryable,
+	clock:           SystemClock,
+	random:          rand.Float64,
+}
+
+// MaxAttempts returns the maximum number of attempts.
+func (p *Policy) MaxAttempts() int {
+	return p.maxAttempts
+}
+
+// WithMaxAttempts sets the maximum number of attempts and returns receiver.
+func (p *Policy) WithMaxAttempts(n int) *Policy {
+	p.maxAttempts = n
+	return p
+}
+
+// InitialInterval returns the interval before the second attempt.
+func (p *Policy) InitialInterval() time.Duration {
+	return p.initialInterval
+}
+
+// WithInitialInterval sets the interval before the second attempt and returns receiver.
+func (p *Policy) WithInitialInterval(d time.Duration) *Policy {
+	p.initialInterval = d
+	return p
+}
+
+// MaxInterval returns the upper bound of the interval between attempts.
+func (p *Policy) MaxInterval() time.Duration {
+	return p.maxInterval
+}
+
+// WithMaxInterval sets the upper bound of the interval and returns receiver.
+// Zero means no upper bound.
+func (p *Policy) WithMaxInterval(d time.Duration) *Policy {
+	p.maxInterval = d
+	return p
+}
+
+// Multiplier returns the backoff multiplier.
+func (p *Policy) Multiplier() float64 {
+	return p.multiplier
+}
+
+// WithMultiplier sets the backoff multiplier and returns receiver.
+func (p *Policy) WithMultiplier(m float64) *Policy {
+	p.multiplier = m
+	return p
+}
+
+// Jitter returns the randomization factor of intervals.
+func (p *Policy) Jitter() float64 {
+	return p.jitter
+}
+
+// WithJitter sets the randomization factor and returns receiver.
+//
+// An interval `d` is randomized in the range [d - d*j, d + d*j].
+func (p *Policy) WithJitter(j float64) *Policy {
+	p.jitter = j
+	return p
+}
+
+// WithRetryable sets the func reports whether the error should be retried and returns receiver.
+func (p *Policy) WithRetryable(f func(error) bool) *Policy {
+	p.retryable = f
+	return p
+}
+
+// WithClock sets Clock and returns receiver.
+func (p *Policy) WithClock(c Clock) *Policy {
+	p.clock = c
+	return p
+}
+
+// WithRandom sets the random source returning values in [0.0, 1.0) and returns receiver.
+func (p *Policy) WithRandom(f func() float64) *Policy {
+	p.random = f
+	return p
+}
+
+// Clone *Policy.
+func (p *Policy) Clone() *Policy {
+	copy := *p
+	return &copy
+}
+
+// Backoff returns the interval to wait after the attempt `n` (1 origin) failed.
+// It is clamped to the max time.Duration if there is no upper bound.
+func (p *Policy) Backoff(n int) time.Duration {
+	d := float64(p.initialInterval) * math.Pow(p.multiplier, float64(n-1))
+	if p.maxInterval > 0 && d > float64(p.maxInterval) {
+		d = float64(p.maxInterval)
+	}
+	if p.jitter > 0 && p.random != nil {
+		d += d * p.jitter * (2*p.random() - 1)
+	}
+	if d < 0 || math.IsNaN(d) {
+		return 0
+	}
+	if d >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(d)
+}
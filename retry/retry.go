@@ -0,0 +1,124 @@
+// Package retry retries funcs according to the classification attached to aerrors' errors.
+package retry
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// Labels of Values read by Do.
+const (
+	RetryableLabel  = "retryable"
+	RetryAfterLabel = "retry_after"
+)
+
+// ErrFailed is the parent of errors returned by Do.
+var ErrFailed = aerrors.New("retry failed")
+
+// Retryable returns Value that classifies the error as retryable or not.
+func Retryable(b bool) *aerrors.Value {
+	return aerrors.Bool(RetryableLabel, b)
+}
+
+// RetryAfter returns Value that hints the interval before the next attempt.
+func RetryAfter(d time.Duration) *aerrors.Value {
+	return aerrors.String(RetryAfterLabel, d.String())
+}
+
+// IsRetryable reports whether the error `err` should be retried.
+//
+// Context errors are not retried. If an *aerrors.Err in the chain has a Value labeled RetryableLabel, it is used.
+// Otherwise the error is retried.
+func IsRetryable(err error) bool {
+	if aerrors.Is(err, context.Canceled) || aerrors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if v, ok := lookup(err, RetryableLabel); ok {
+		b, err := strconv.ParseBool(v)
+		return err != nil || b
+	}
+	return true
+}
+
+// RetryAfterHint returns the interval hinted by the Value labeled RetryAfterLabel.
+func RetryAfterHint(err error) (d time.Duration, ok bool) {
+	v, ok := lookup(err, RetryAfterLabel)
+	if !ok {
+		return 0, false
+	}
+	d, perr := time.ParseDuration(v)
+	if perr != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// lookup returns the text of the value labeled `label`. Lazy values are evaluated.
+func lookup(err error, label string) (string, bool) {
+	v, ok := aerrors.ValueOf(err, label)
+	if !ok {
+		return "", false
+	}
+	return v.Text(), true
+}
+
+// Do calls `fn` until it succeeds, returns an error that should not be retried, or attempts are exhausted.
+//
+// The interval between attempts is the exponential backoff of the policy,
+// or the hint of the Value labeled RetryAfterLabel if the error has it.
+// If `policy` is nil, DefaultPolicy is used.
+//
+// The returned error is a child of ErrFailed wrapping the last error, and has Values labeled "attempts" and "elapsed".
+// If `ctx` is done while waiting, it wraps both the context error and the last error.
+func Do(ctx context.Context, fn func(context.Context) error, policy *Policy) error {
+	if policy == nil {
+		policy = DefaultPolicy
+	}
+	clock := policy.clock
+	if clock == nil {
+		clock = SystemClock
+	}
+	retryable := policy.retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	start := clock.Now()
+	attempt := 0
+	for {
+		attempt++
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt >= policy.maxAttempts || !retryable(err) {
+			return failed(attempt, clock.Now().Sub(start), err)
+		}
+
+		wait, ok := RetryAfterHint(err)
+		if !ok {
+			wait = policy.Backoff(attempt)
+		}
+		select {
+		case <-ctx.Done():
+			return failed(attempt, clock.Now().Sub(start), ctx.Err(), err)
+		case <-clock.After(wait):
+		}
+	}
+}
+
+// failed returns the child of ErrFailed wrapping `errs`.
+func failed(attempts int, elapsed time.Duration, errs ...error) *aerrors.Err {
+	format := "retry failed after %d attempts" + strings.Repeat(": %w", len(errs))
+	args := []interface{}{attempts}
+	for _, err := range errs {
+		args = append(args, err)
+	}
+	return ErrFailed.Errorf(format, args...).
+		WithInt("attempts", attempts).
+		WithString("elapsed", elapsed.String())
+}
@@ -0,0 +1,194 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+)
+
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestDo(t *testing.T) {
+	oops := errors.New("oops")
+	cases := []struct {
+		errs      []error
+		policy    *Policy
+		wantCalls int
+		wantWaits []time.Duration
+		wantErr   bool
+		wantValue map[string]string
+	}{
+		{
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			errs:      []error{oops, oops, nil},
+			wantCalls: 3,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			errs:      []error{oops, oops, oops, oops},
+			wantCalls: 3,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second},
+			wantErr:   true,
+			wantValue: map[string]string{"attempts": "3", "elapsed": "3s"},
+		},
+		{
+			errs:      []error{aerrors.New("permanent").WithValue(Retryable(false)), nil},
+			wantCalls: 1,
+			wantErr:   true,
+			wantValue: map[string]string{"attempts": "1", "elapsed": "0s"},
+		},
+		{
+			errs:      []error{aerrors.New("busy").WithValue(RetryAfter(5 * time.Second)), nil},
+			wantCalls: 2,
+			wantWaits: []time.Duration{5 * time.Second},
+		},
+		{
+			errs:      []error{oops, oops, oops, nil},
+			policy:    newPolicy().WithMaxAttempts(5).WithMaxInterval(3 * time.Second),
+			wantCalls: 4,
+			wantWaits: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		{
+			errs:      []error{oops, oops, nil},
+			policy:    newPolicy().WithJitter(0.5).WithRandom(func() float64 { return 0 }),
+			wantCalls: 3,
+			wantWaits: []time.Duration{500 * time.Millisecond, time.Second},
+		},
+	}
+
+	for i, tc := range cases {
+		policy := tc.policy
+		if policy == nil {
+			policy = newPolicy()
+		}
+		clock := &fakeClock{now: time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)}
+		policy.WithClock(clock)
+
+		calls := 0
+		err := Do(context.Background(), func(context.Context) error {
+			err := tc.errs[calls]
+			calls++
+			return err
+		}, policy)
+
+		if calls != tc.wantCalls {
+			t.Errorf("#%d: calls == %d, want %d", i, calls, tc.wantCalls)
+		}
+		if !reflect.DeepEqual(clock.waits, tc.wantWaits) {
+			t.Errorf("#%d: waits == %v, want %v", i, clock.waits, tc.wantWaits)
+		}
+		if (err != nil) != tc.wantErr {
+			t.Errorf("#%d: err == %v, want error: %v", i, err, tc.wantErr)
+			continue
+		}
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrFailed) || !errors.Is(err, tc.errs[calls-1]) {
+			t.Errorf("#%d: err == %v, want ErrFailed wrapping %v", i, err, tc.errs[calls-1])
+		}
+		e, _ := aerrors.AsErr(err)
+		for _, v := range e.Values() {
+			if want, ok := tc.wantValue[v.Label]; ok && v.Value != want {
+				t.Errorf("#%d: value %s == %s, want %s", i, v.Label, v.Value, want)
+			}
+		}
+	}
+}
+
+func TestDo_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	oops := errors.New("oops")
+	policy := newPolicy().WithClock(&blockingClock{})
+	err := Do(ctx, func(context.Context) error {
+		return oops
+	}, policy)
+
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, oops) {
+		t.Errorf("err == %v, want context.Canceled and the last error", err)
+	}
+	if want := "retry failed after 1 attempts: context canceled: oops"; err.Error() != want {
+		t.Errorf("err == %q, want %q", err, want)
+	}
+	if v, ok := aerrors.ValueOf(err, "attempts"); !ok || v.Text() != "1" {
+		t.Errorf("attempts == %v, %v", v, ok)
+	}
+	if _, ok := aerrors.ValueOf(err, "elapsed"); !ok {
+		t.Error("err has no elapsed")
+	}
+}
+
+func TestIsRetryable_cycle(t *testing.T) {
+	a := aerrors.New("a")
+	a.WithError(a)
+	if !IsRetryable(a) {
+		t.Error("IsRetryable(cycle) == false, want true")
+	}
+	if IsRetryable(a.Wrap(context.Canceled)) {
+		t.Error("IsRetryable(canceled) == true, want false")
+	}
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	cases := []struct {
+		policy *Policy
+		n      int
+		want   time.Duration
+	}{
+		{policy: newPolicy(), n: 1, want: time.Second},
+		{policy: newPolicy(), n: 3, want: 4 * time.Second},
+		{policy: newPolicy(), n: 100, want: time.Minute},
+		{policy: newPolicy().WithMaxInterval(0), n: 100, want: math.MaxInt64},
+		{policy: newPolicy().WithMaxInterval(0), n: 10000, want: math.MaxInt64},
+		{policy: newPolicy().WithMaxInterval(0).WithJitter(0.5).WithRandom(func() float64 { return 0.99 }), n: 100, want: math.MaxInt64},
+		{policy: newPolicy().WithMaxInterval(0).WithInitialInterval(0), n: 10000, want: 0},
+	}
+
+	for i, tc := range cases {
+		if got := tc.policy.Backoff(tc.n); got != tc.want {
+			t.Errorf("#%d: Backoff(%d) == %v, want %v", i, tc.n, got, tc.want)
+		}
+	}
+}
+
+type blockingClock struct{}
+
+func (blockingClock) Now() time.Time {
+	return time.Time{}
+}
+
+func (blockingClock) After(time.Duration) <-chan time.Time {
+	return nil
+}
+
+func newPolicy() *Policy {
+	return DefaultPolicy.Clone().
+		WithInitialInterval(time.Second).
+		WithMaxInterval(time.Minute).
+		WithJitter(0)
+}
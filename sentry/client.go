@@ -0,0 +1,126 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// ErrUnexpectedStatus is the parent of errors returned when the endpoint responds non 2xx status.
+var ErrUnexpectedStatus = aerrors.New("sentry: unexpected status")
+
+// Envelope encodes the event to Sentry envelope sent now.
+func Envelope(ev *Event) ([]byte, error) {
+	return envelope(ev, time.Now().UTC())
+}
+
+// envelope encodes the event to Sentry envelope sent at `sentAt`.
+func envelope(ev *Event, sentAt time.Time) ([]byte, error) {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+	header, err := json.Marshal(struct {
+		EventID string    `json:"event_id"`
+		SentAt  time.Time `json:"sent_at"`
+	}{ev.EventID, sentAt})
+	if err != nil {
+		return nil, err
+	}
+	item, err := json.Marshal(struct {
+		Type   string `json:"type"`
+		Length int    `json:"length"`
+	}{"event", len(payload)})
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	b.Write(header)
+	b.WriteByte('\n')
+	b.Write(item)
+	b.WriteByte('\n')
+	b.Write(payload)
+	b.WriteByte('\n')
+	return b.Bytes(), nil
+}
+
+// Client sends events to Sentry compatible endpoint.
+type Client struct {
+	url        string
+	enc        *Encoder
+	httpClient *http.Client
+	header     http.Header
+}
+
+// NewClient returns Client sends envelopes to `url`.
+// If `enc` is nil, NewEncoder() is used.
+func NewClient(url string, enc *Encoder) *Client {
+	if enc == nil {
+		enc = NewEncoder()
+	}
+	return &Client{
+		url:        url,
+		enc:        enc,
+		httpClient: http.DefaultClient,
+		header:     http.Header{},
+	}
+}
+
+// WithHTTPClient sets *http.Client and returns receiver.
+func (c *Client) WithHTTPClient(hc *http.Client) *Client {
+	c.httpClient = hc
+	return c
+}
+
+// WithHeader adds HTTP request header and returns receiver.
+func (c *Client) WithHeader(key, value string) *Client {
+	c.header.Add(key, value)
+	return c
+}
+
+// Send encodes the error `err` and sends it.
+func (c *Client) Send(ctx context.Context, err error) error {
+	body, eerr := envelope(c.enc.Event(err), c.enc.now().UTC())
+	if eerr != nil {
+		return eerr
+	}
+
+	req, rerr := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if rerr != nil {
+		return rerr
+	}
+	for k, vs := range c.header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+
+	res, rerr := c.httpClient.Do(req)
+	if rerr != nil {
+		return rerr
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode/100 != 2 {
+		return ErrUnexpectedStatus.Errorf("sentry: unexpected status: %s", res.Status).WithInt("status", res.StatusCode)
+	}
+	return nil
+}
+
+// Report implements report.Reporter.
+// It sends each error and returns the first error.
+func (c *Client) Report(ctx context.Context, errs []*aerrors.Err) error {
+	var first error
+	for _, e := range errs {
+		if err := c.Send(ctx, e); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
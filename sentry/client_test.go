@@ -0,0 +1,180 @@
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+	"github.com/kamiaka/aerrors/report"
+)
+
+var _ report.Reporter = (*Client)(nil)
+
+func TestClient_Send(t *testing.T) {
+	var (
+		body   []byte
+		header http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer server.Close()
+
+	now := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	enc := NewEncoder().
+		WithTags("user_id").
+		WithEnvironment("test").
+		WithClock(func() time.Time { return now }).
+		WithEventID(func() string { return "0123456789abcdef0123456789abcdef" })
+	client := NewClient(server.URL, enc).WithHeader("X-Sentry-Auth", "Sentry sentry_key=key")
+
+	errTime := time.Date(2001, time.February, 3, 4, 5, 5, 0, time.FixedZone("+0900", 9*3600))
+	appError := aerrors.New("app error",
+		aerrors.Clock(func() time.Time { return errTime }),
+		aerrors.GenerateID(func(time.Time) string { return "01ARZ3NDEKTSV4RRFFQ69G5FAV" }))
+	origin := errors.New("connection refused")
+	err := appError.Errorf("query: %w", fmt.Errorf("dial: %w", origin)).
+		WithString("user_id", "42").
+		WithString("query", "SELECT 1")
+
+	if err := client.Send(context.Background(), err); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := header.Get("Content-Type"); got != "application/x-sentry-envelope" {
+		t.Errorf("Content-Type: %s", got)
+	}
+	if got := header.Get("X-Sentry-Auth"); got != "Sentry sentry_key=key" {
+		t.Errorf("X-Sentry-Auth: %s", got)
+	}
+
+	lines := bytes.Split(bytes.TrimSuffix(body, []byte("\n")), []byte("\n"))
+	if len(lines) != 3 {
+		t.Fatalf("envelope:\n%s", body)
+	}
+	if want := `{"event_id":"0123456789abcdef0123456789abcdef","sent_at":"2001-02-03T04:05:06Z"}`; string(lines[0]) != want {
+		t.Errorf("header == %s, want %s", lines[0], want)
+	}
+	if want := fmt.Sprintf(`{"type":"event","length":%d}`, len(lines[2])); string(lines[1]) != want {
+		t.Errorf("item header == %s, want %s", lines[1], want)
+	}
+
+	var ev Event
+	if err := json.Unmarshal(lines[2], &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Level != "error" || ev.Environment != "test" || ev.Platform != "go" {
+		t.Errorf("event: %+v", ev)
+	}
+	if want := []string{err.Fingerprint()}; !reflect.DeepEqual(ev.Fingerprint, want) {
+		t.Errorf("fingerprint == %v, want %v", ev.Fingerprint, want)
+	}
+	if !ev.Timestamp.Equal(errTime) {
+		t.Errorf("timestamp == %v, want %v", ev.Timestamp, errTime)
+	}
+	if want := map[string]string{"user_id": "42", ErrorIDTag: "01ARZ3NDEKTSV4RRFFQ69G5FAV"}; !reflect.DeepEqual(ev.Tags, want) {
+		t.Errorf("tags == %v, want %v", ev.Tags, want)
+	}
+	if want := map[string]string{"query": "SELECT 1"}; !reflect.DeepEqual(ev.Extra, want) {
+		t.Errorf("extra == %v, want %v", ev.Extra, want)
+	}
+
+	var types []string
+	for _, ex := range ev.Exception.Values {
+		types = append(types, ex.Type+": "+ex.Value)
+	}
+	wantTypes := []string{
+		"*errors.errorString: connection refused",
+		"*fmt.wrapError: dial: connection refused",
+		"app error: query: dial: connection refused",
+	}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Errorf("exceptions == %#v, want %#v", types, wantTypes)
+	}
+
+	st := ev.Exception.Values[2].Stacktrace
+	if st == nil || len(st.Frames) == 0 {
+		t.Fatalf("stacktrace is empty")
+	}
+	last := st.Frames[len(st.Frames)-1]
+	if last.Function != "TestClient_Send" || last.Module != "github.com/kamiaka/aerrors/sentry" || !strings.HasSuffix(last.Filename, "sentry/client_test.go") {
+		t.Errorf("last frame: %+v", last)
+	}
+}
+
+func TestClient_Send_status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	err := NewClient(server.URL, nil).Send(context.Background(), aerrors.New("oops"))
+	if !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("err == %v, want ErrUnexpectedStatus", err)
+	}
+}
+
+func TestLevel(t *testing.T) {
+	cases := []struct {
+		priority aerrors.ErrorPriority
+		want     string
+	}{
+		{priority: aerrors.Emergency, want: "fatal"},
+		{priority: aerrors.Error, want: "error"},
+		{priority: aerrors.Warning, want: "warning"},
+		{priority: aerrors.Debug, want: "debug"},
+		{priority: aerrors.ErrorPriority(-1), want: "fatal"},
+		{priority: aerrors.ErrorPriority(99), want: "debug"},
+	}
+	for i, tc := range cases {
+		if got := Level(tc.priority); got != tc.want {
+			t.Errorf("#%d: Level(%v) == %#v, want %#v", i, tc.priority, got, tc.want)
+		}
+	}
+}
+
+func TestEncoder_Event_limits(t *testing.T) {
+	err := aerrors.New("first", aerrors.Limit(aerrors.Limits{MaxValueLen: 22, MaxValues: 1, MaxDepth: 2})).
+		WithString("query", strings.Repeat("x", 100)).
+		WithString("dropped", "value").
+		Wrap(aerrors.New("second").Wrap(aerrors.New("third")))
+
+	ev := NewEncoder().Event(err)
+
+	want := map[string]string{"query": "xxx... (97 more bytes)", aerrors.MoreLabel: "1 more values"}
+	if !reflect.DeepEqual(ev.Extra, want) {
+		t.Errorf("extra == %v, want %v", ev.Extra, want)
+	}
+	var values []string
+	for _, ex := range ev.Exception.Values {
+		values = append(values, ex.Value)
+	}
+	if want := []string{aerrors.DepthMarker, "second", "first"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("exceptions == %#v, want %#v", values, want)
+	}
+}
+
+func TestEncoder_Event_cycle(t *testing.T) {
+	a, b := aerrors.New("a"), aerrors.New("b")
+	a.WithError(b)
+	b.WithError(a)
+
+	var values []string
+	for _, ex := range NewEncoder().Event(a).Exception.Values {
+		values = append(values, ex.Value)
+	}
+	if want := []string{aerrors.CycleMarker, "b", "a"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("exceptions == %#v, want %#v", values, want)
+	}
+}
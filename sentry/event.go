@@ -0,0 +1,303 @@
+// Package sentry encodes aerrors' errors to Sentry events and sends them.
+//
+// Client implements report.Reporter.
+//
+//	client := sentry.NewClient("https://sentry.example.com/api/1/envelope/", nil).
+//		WithHeader("X-Sentry-Auth", "Sentry sentry_version=7, sentry_key=...")
+//	d := report.NewDispatcher(client, nil)
+package sentry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/kamiaka/aerrors"
+)
+
+// Event is Sentry event payload.
+type Event struct {
+	EventID     string            `json:"event_id"`
+	Timestamp   time.Time         `json:"timestamp"`
+	Platform    string            `json:"platform"`
+	Level       string            `json:"level"`
+	Message     string            `json:"message,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Release     string            `json:"release,omitempty"`
+	ServerName  string            `json:"server_name,omitempty"`
+	Exception   *Exceptions       `json:"exception,omitempty"`
+	Fingerprint []string          `json:"fingerprint,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+}
+
+// Exceptions is the exception interface of Event.
+type Exceptions struct {
+	Values []*Exception `json:"values"`
+}
+
+// Exception is an error in the chain.
+type Exception struct {
+	Type       string      `json:"type"`
+	Value      string      `json:"value"`
+	Stacktrace *Stacktrace `json:"stacktrace,omitempty"`
+}
+
+// Stacktrace of Exception.
+type Stacktrace struct {
+	Frames []*Frame `json:"frames"`
+}
+
+// Frame of Stacktrace.
+type Frame struct {
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Lineno   int    `json:"lineno"`
+}
+
+// Levels are Sentry levels for priorities.
+//
+// It can overwrite for user defined priority.
+// Priorities not in the map are "fatal" if higher than aerrors.Critical, otherwise "debug".
+var Levels = map[aerrors.ErrorPriority]string{
+	aerrors.Emergency: "fatal",
+	aerrors.Alert:     "fatal",
+	aerrors.Critical:  "fatal",
+	aerrors.Error:     "error",
+	aerrors.Warning:   "warning",
+	aerrors.Notice:    "info",
+	aerrors.Info:      "info",
+	aerrors.Debug:     "debug",
+}
+
+// Level returns Sentry level for the priority.
+func Level(p aerrors.ErrorPriority) string {
+	if level, ok := Levels[p]; ok {
+		return level
+	}
+	if p.HigherThan(aerrors.Critical) {
+		return "fatal"
+	}
+	return "debug"
+}
+
+// Encoder encodes errors to Event.
+type Encoder struct {
+	tags        map[string]bool
+	environment string
+	release     string
+	serverName  string
+	now         func() time.Time
+	newID       func() string
+}
+
+// ErrorIDTag is the tag of the ID of the error.
+const ErrorIDTag = "error_id"
+
+// NewEncoder returns new Encoder.
+func NewEncoder() *Encoder {
+	return &Encoder{
+		tags:  map[string]bool{},
+		now:   time.Now,
+		newID: newEventID,
+	}
+}
+
+// WithTags sets labels of Values encoded as tags and returns receiver.
+// Other Values are encoded as extra.
+// Values of groups are labeled by flattened labels, e.g. "db.query".
+func (enc *Encoder) WithTags(labels ...string) *Encoder {
+	for _, l := range labels {
+		enc.tags[l] = true
+	}
+	return enc
+}
+
+// WithEnvironment sets environment and returns receiver.
+func (enc *Encoder) WithEnvironment(env string) *Encoder {
+	enc.environment = env
+	return enc
+}
+
+// WithRelease sets release and returns receiver.
+func (enc *Encoder) WithRelease(release string) *Encoder {
+	enc.release = release
+	return enc
+}
+
+// WithServerName sets server name and returns receiver.
+func (enc *Encoder) WithServerName(name string) *Encoder {
+	enc.serverName = name
+	return enc
+}
+
+// WithClock sets the func returns current time and returns receiver.
+func (enc *Encoder) WithClock(now func() time.Time) *Encoder {
+	enc.now = now
+	return enc
+}
+
+// WithEventID sets the func generates event ID and returns receiver.
+func (enc *Encoder) WithEventID(newID func() string) *Encoder {
+	enc.newID = newID
+	return enc
+}
+
+// Event encodes the error `err` to Event.
+//
+// Exception values are ordered from the innermost wrapped error to `err` as Sentry expects.
+// Level, fingerprint and timestamp are determined by the first *aerrors.Err in the chain, and its ID is encoded as the tag ErrorIDTag.
+// Values of *aerrors.Err in the chain are encoded as tags or extra, the outer error's value takes precedence.
+//
+// Limits of the first *aerrors.Err are honored: values are limited by MaxValues and MaxValueLen,
+// and errors deeper than MaxDepth are encoded as the exception has aerrors.DepthMarker.
+// The error already encoded in a cycle is encoded as the exception has aerrors.CycleMarker.
+func (enc *Encoder) Event(err error) *Event {
+	ev := &Event{
+		EventID:     enc.newID(),
+		Timestamp:   enc.now().UTC(),
+		Platform:    "go",
+		Level:       "error",
+		Message:     err.Error(),
+		Environment: enc.environment,
+		Release:     enc.release,
+		ServerName:  enc.serverName,
+		Exception:   &Exceptions{},
+	}
+
+	var limits aerrors.Limits
+	if e, ok := aerrors.AsErr(err); ok {
+		limits = e.Limits()
+	}
+	first := true
+	seen := map[error]bool{}
+	for depth := 0; err != nil; depth++ {
+		if depth >= limits.Depth() {
+			ev.Exception.Values = append([]*Exception{markerException(aerrors.DepthMarker)}, ev.Exception.Values...)
+			break
+		}
+		if t := reflect.TypeOf(err); t.Comparable() {
+			if seen[err] {
+				ev.Exception.Values = append([]*Exception{markerException(aerrors.CycleMarker)}, ev.Exception.Values...)
+				break
+			}
+			seen[err] = true
+		}
+		ex := &Exception{
+			Type:  fmt.Sprintf("%T", err),
+			Value: err.Error(),
+		}
+		if e, ok := err.(*aerrors.Err); ok {
+			if first {
+				ev.Level = Level(e.Priority())
+				ev.Fingerprint = []string{e.Fingerprint()}
+				if t := e.Time(); !t.IsZero() {
+					ev.Timestamp = t.UTC()
+				}
+				if id := e.ID(); id != "" {
+					ev.Tags = map[string]string{ErrorIDTag: id}
+				}
+				first = false
+			}
+			ex.Type = rootMessage(e)
+			ex.Stacktrace = stacktrace(e.Callers())
+			enc.addValues(ev, limits.Values(e.Values()))
+		}
+		ev.Exception.Values = append([]*Exception{ex}, ev.Exception.Values...)
+		err = errors.Unwrap(err)
+	}
+	return ev
+}
+
+// markerException returns Exception in place of errors not encoded.
+func markerException(marker string) *Exception {
+	return &Exception{Type: marker, Value: marker}
+}
+
+func (enc *Encoder) addValues(ev *Event, values []*aerrors.Value) {
+	var flat []*aerrors.Value
+	for _, v := range values {
+		flat = append(flat, v.Flatten()...)
+	}
+	for _, v := range flat {
+		m := &ev.Extra
+		if enc.tags[v.Label] {
+			m = &ev.Tags
+		}
+		if *m == nil {
+			*m = map[string]string{}
+		}
+		if _, ok := (*m)[v.Label]; !ok {
+			(*m)[v.Label] = v.Text()
+		}
+	}
+}
+
+func rootMessage(e *aerrors.Err) string {
+	for e.Parent() != nil {
+		e = e.Parent()
+	}
+	return e.Error()
+}
+
+// stacktrace returns Stacktrace ordered from the oldest frame as Sentry expects.
+func stacktrace(frames *runtime.Frames) *Stacktrace {
+	var list []*Frame
+	for {
+		frame, more := frames.Next()
+		if frame.Function == "runtime.main" || frame.PC == 0 {
+			break
+		}
+		module, function := splitFunc(frame.Function)
+		list = append([]*Frame{{
+			Function: function,
+			Module:   module,
+			Filename: filename(frame.File, module),
+			AbsPath:  frame.File,
+			Lineno:   frame.Line,
+		}}, list...)
+		if !more {
+			break
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return &Stacktrace{Frames: list}
+}
+
+// splitFunc splits the function name to package path and function.
+//
+//	e.g., path/to/pkg.(*Type).Method returns "path/to/pkg" and "(*Type).Method"
+func splitFunc(name string) (module, function string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+1+dot+1:]
+}
+
+// filename returns the base name of the file prefixed with the package path.
+func filename(file, module string) string {
+	if i := strings.LastIndex(file, "/"); i >= 0 {
+		file = file[i+1:]
+	}
+	if module == "" {
+		return file
+	}
+	return module + "/" + file
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
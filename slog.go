@@ -0,0 +1,172 @@
+package aerrors
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Attr returns slog.Attr of the value.
+// The group and the map are converted to the group attribute, the string slice to []string and the duration to time.Duration.
+func (v *Value) Attr() slog.Attr {
+	attr, _ := v.attr(nil)
+	return attr
+}
+
+// attr returns slog.Attr of the value fitted in the budget, and whether any of it is fitted.
+func (v *Value) attr(b *logBudget) (slog.Attr, bool) {
+	switch v.Kind {
+	case KindGroup, KindMap:
+		attrs := make([]slog.Attr, 0, len(v.Group))
+		for _, g := range v.Group {
+			if attr, ok := g.attr(b); ok {
+				attrs = append(attrs, attr)
+			}
+		}
+		return slog.Attr{Key: v.Label, Value: slog.GroupValue(attrs...)}, len(attrs) > 0 || len(v.Group) == 0
+	case KindStrings:
+		s := make([]string, 0, len(v.Group))
+		for _, g := range v.Group {
+			text, ok := b.fit(v.Label, g.Text())
+			if !ok {
+				break
+			}
+			s = append(s, text)
+		}
+		return slog.Any(v.Label, s), len(s) > 0 || len(v.Group) == 0
+	case KindDuration:
+		if d, err := time.ParseDuration(v.Text()); err == nil {
+			_, ok := b.fit(v.Label, v.Text())
+			return slog.Duration(v.Label, d), ok
+		}
+	}
+	text, ok := b.fit(v.Label, v.Text())
+	return slog.String(v.Label, text), ok
+}
+
+// logBudget is the rest of Limits.MaxBytes counted by bytes of keys and texts logged by LogValue.
+// The nil budget is unlimited.
+type logBudget struct {
+	left      int
+	truncated bool
+}
+
+func newLogBudget(l Limits) *logBudget {
+	if l.MaxBytes <= 0 {
+		return nil
+	}
+	return &logBudget{left: l.MaxBytes}
+}
+
+// fit returns `text` truncated to fit the budget with `key`, and whether it is fitted.
+func (b *logBudget) fit(key, text string) (string, bool) {
+	if b == nil {
+		return text, true
+	}
+	if n := len(key) + len(text); n <= b.left {
+		b.left -= n
+		return text, true
+	}
+	b.truncated = true
+	if b.left <= len(key) {
+		b.left = 0
+		return "", false
+	}
+	text = truncate(text, b.left-len(key))
+	b.left = 0
+	return text, true
+}
+
+// fitAll reports whether `text` fits the budget with `key` without truncation, and consumes the budget if it fits.
+func (b *logBudget) fitAll(key, text string) bool {
+	if b == nil {
+		return true
+	}
+	if n := len(key) + len(text); n <= b.left {
+		b.left -= n
+		return true
+	}
+	b.truncated = true
+	return false
+}
+
+// LogValue implements interface `slog.LogValuer`.
+//
+// The error is logged as the group has message, priority, parents' messages, time, error_id, values and wrapped error.
+// The wrapped error other than *Err is logged as the message.
+//
+// Errors in a cycle are logged as CycleMarker.
+// The output is limited by Limits of the config. MaxBytes is counted by bytes of keys and texts,
+// and attributes over it are dropped and "truncated" is logged as true.
+func (e *Err) LogValue() slog.Value {
+	b := newLogBudget(e.limits)
+	value := logValueError(e, e.limits.visitor(), b)
+	if b == nil || !b.truncated {
+		return value
+	}
+	return slog.GroupValue(append(value.Group(), slog.Bool("truncated", true))...)
+}
+
+func (e *Err) logValue(v *visitor, b *logBudget) slog.Value {
+	var attrs []slog.Attr
+	add := func(key, text string) {
+		if text, ok := b.fit(key, text); ok {
+			attrs = append(attrs, slog.String(key, text))
+		}
+	}
+	add("message", e.msg)
+	add("priority", e.priority.String())
+	if parents := e.parents(); len(parents) > 0 {
+		msgs := make([]string, 0, len(parents))
+		for _, parent := range parents {
+			msg, ok := b.fit("parents", parent.msg)
+			if !ok {
+				break
+			}
+			msgs = append(msgs, msg)
+		}
+		if len(msgs) > 0 {
+			attrs = append(attrs, slog.Any("parents", msgs))
+		}
+	}
+	// the time and the ID are logged only if they fit without truncation.
+	if !e.time.IsZero() && b.fitAll("time", e.jsonTime()) {
+		attrs = append(attrs, slog.Time("time", e.time))
+	}
+	if e.id != "" && b.fitAll("error_id", e.id) {
+		attrs = append(attrs, slog.String("error_id", e.id))
+	}
+	if limited := e.limits.Values(e.values); len(limited) > 0 {
+		values := make([]slog.Attr, 0, len(limited))
+		for _, value := range limited {
+			if attr, ok := value.attr(b); ok {
+				values = append(values, attr)
+			}
+		}
+		if len(values) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "values", Value: slog.GroupValue(values...)})
+		}
+	}
+	if e.wrappedError != nil {
+		if wrapped := logValueError(e.wrappedError, v, b); wrapped.Kind() != slog.KindGroup || len(wrapped.Group()) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "wrapped", Value: wrapped})
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// logValueError returns slog.Value of `err`. It is the empty group if nothing is fitted in the budget.
+func logValueError(err error, v *visitor, b *logBudget) slog.Value {
+	if marker, ok := v.visit(err); !ok {
+		if marker, ok := b.fit("wrapped", marker); ok {
+			return slog.StringValue(marker)
+		}
+		return slog.GroupValue()
+	}
+	if e, ok := err.(*Err); ok {
+		return e.logValue(v, b)
+	}
+	if msg, ok := b.fit("wrapped", err.Error()); ok {
+		return slog.StringValue(msg)
+	}
+	return slog.GroupValue()
+}
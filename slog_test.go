@@ -0,0 +1,24 @@
+package aerrors
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+)
+
+func ExampleErr_LogValue() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	appError := New("app error", Clock(testClock), GenerateID(testIDGenerator))
+	err := appError.Wrap(errors.New("oops")).WithGroup("db", String("table", "users"), Int("id", 42))
+
+	logger.Error("request failed", "error", err)
+	// Output:
+	// {"level":"ERROR","msg":"request failed","error":{"message":"app error","priority":"Error","parents":["app error"],"time":"2001-02-03T04:05:06.000000007Z","error_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","values":{"db":{"table":"users","id":"42"}},"wrapped":"oops"}}
+}
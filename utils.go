@@ -1,15 +1,72 @@
 package aerrors
 
-import "errors"
+import (
+	"errors"
+	"reflect"
+)
 
 // AsErr returns casted `*Err` error and whether cas succeeded.
 //
-// It is the same as the code below.
-//   var e *aerrors.Err
-//   ok := errors.As(&e)
+// It is the same as the code below, so it also follows `Unwrap() []error` and `As(interface{}) bool`.
+//
+//	var e *aerrors.Err
+//	ok := errors.As(err, &e)
 func AsErr(err error) (e *Err, ok bool) {
 	if errors.As(err, &e) {
 		return e, true
 	}
 	return nil, false
 }
+
+// Is reports whether any error in the tree of `err` matches `target`, the same as errors.Is.
+//
+// Unlike errors.Is, it walks the tree by Walk, so it never hangs on errors linked in a cycle.
+func Is(err, target error) bool {
+	if err == nil || target == nil {
+		return err == target
+	}
+	comparable := reflect.TypeOf(target).Comparable()
+	found := false
+	Walk(err, func(err error) bool {
+		if comparable && err == target {
+			found = true
+		} else if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(target) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// Walk calls `fn` for `err` and its wrapped errors in depth-first pre-order, until `fn` returns false.
+//
+// It follows both `Unwrap() error` and `Unwrap() []error`,
+// so it traverses trees produced by errors.Join and fmt.Errorf with multiple `%w`.
+//
+// Errors linked in a cycle are visited only once, and errors deeper than MaxChainDepth are not visited.
+func Walk(err error, fn func(error) bool) {
+	walk(err, fn, newVisitor())
+}
+
+func walk(err error, fn func(error) bool, v *visitor) bool {
+	if err == nil {
+		return true
+	}
+	if _, ok := v.visit(err); !ok {
+		return true
+	}
+	if !fn(err) {
+		return false
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(u.Unwrap(), fn, v)
+	case interface{ Unwrap() []error }:
+		for _, err := range u.Unwrap() {
+			if !walk(err, fn, v.fork()) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -3,6 +3,8 @@ package aerrors
 import (
 	"errors"
 	"fmt"
+	"io"
+	"testing"
 )
 
 func ExampleAsErr() {
@@ -29,3 +31,55 @@ func ExampleAsErr() {
 	// #2: false, <nil>
 	// #3: true, child error
 }
+
+func ExampleAsErr_joined() {
+	err := errors.Join(errors.New("other error"), fmt.Errorf("wrapped: %w", New("app error")))
+
+	e, ok := AsErr(err)
+	fmt.Println(ok, e)
+	// Output:
+	// true app error
+}
+
+func ExampleWalk() {
+	err := Errorf("%w and %w", New("not found"), fmt.Errorf("timeout: %w", errors.New("deadline exceeded")))
+
+	Walk(err, func(err error) bool {
+		fmt.Printf("%T\n", err)
+		return err.Error() != "timeout: deadline exceeded"
+	})
+	// Output:
+	// *aerrors.Err
+	// *errors.joinError
+	// *aerrors.Err
+	// *fmt.wrapError
+}
+
+func TestIs(t *testing.T) {
+	self := New("self")
+	self.WithError(self)
+	a, b := New("a"), New("b")
+	a.WithError(b)
+	b.WithError(a)
+	child := New("parent").New("child")
+
+	cases := []struct {
+		err, target error
+		want        bool
+	}{
+		{err: nil, target: nil, want: true},
+		{err: self, target: nil, want: false},
+		{err: self, target: self, want: true},
+		{err: self, target: io.EOF, want: false},
+		{err: fmt.Errorf("wrapped: %w", a), target: b, want: true},
+		{err: errors.Join(a, io.EOF), target: io.EOF, want: true},
+		{err: a, target: io.EOF, want: false},
+		{err: child, target: child.Parent(), want: true},
+	}
+
+	for i, tc := range cases {
+		if got := Is(tc.err, tc.target); got != tc.want {
+			t.Errorf("#%d: Is(%v, %v) == %v, want %v", i, tc.err, tc.target, got, tc.want)
+		}
+	}
+}
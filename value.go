@@ -2,7 +2,12 @@ package aerrors
 
 import (
 	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kamiaka/aerrors/internal/stack"
@@ -11,9 +16,56 @@ import (
 // Value is labeled value.
 //
 // Using `(*Err).With(values...)`
+//
+// Use Text to get the rendered value, because the field Value of lazy values is empty.
 type Value struct {
 	Label string
+	// Value is the rendered text. It is empty for lazy values, use Text instead.
 	Value string
+	Kind  Kind
+	Group []*Value
+	lazy  *lazyValue
+
+	// bytes is the copy of the bytes Value, rendered by BytesFormat of the config when it is added to the error.
+	// It is dropped once rendered, and bytesFixed is set. Lazy bytes Values keep the func in lazy instead.
+	bytes      []byte
+	bytesFixed bool
+}
+
+// Kind is kind of Value.
+type Kind int
+
+// Kinds of Value.
+const (
+	KindString Kind = iota
+	KindGroup
+	KindDuration
+	KindError
+	KindIP
+	KindURL
+	KindStrings
+	KindMap
+	KindBytes
+)
+
+var kindNames = map[Kind]string{
+	KindString:   "String",
+	KindGroup:    "Group",
+	KindDuration: "Duration",
+	KindError:    "Error",
+	KindIP:       "IP",
+	KindURL:      "URL",
+	KindStrings:  "Strings",
+	KindMap:      "Map",
+	KindBytes:    "Bytes",
+}
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
 }
 
 type Values []*Value
@@ -24,9 +76,41 @@ func (v *Value) WithLabel(l string) *Value {
 	return v
 }
 
-// String returns label and value string.
+// GoString returns Go syntax of the value.
+// Lazy values are not evaluated and rendered as LazyMarker.
+func (v *Value) GoString() string {
+	return "&aerrors.Value" + v.goString()
+}
+
+func (v *Value) goString() string {
+	if v.Kind == KindGroup {
+		s := make([]string, len(v.Group))
+		for i, g := range v.Group {
+			s[i] = g.goString()
+		}
+		return fmt.Sprintf("{Label: %q, Kind: aerrors.KindGroup, Group: []*aerrors.Value{%s}}", v.Label, strings.Join(s, ", "))
+	}
+	if v.lazy != nil {
+		return fmt.Sprintf("{Label: %q, Value: %s}", v.Label, LazyMarker)
+	}
+	if v.Kind != KindString {
+		return fmt.Sprintf("{Label: %q, Value: %q, Kind: aerrors.Kind%s}", v.Label, v.Value, v.Kind)
+	}
+	return fmt.Sprintf("{Label: %q, Value: %q}", v.Label, v.Value)
+}
+
+// String returns label and value string. It evaluates lazy values as Text.
+// Values of the group are joined with ", ", e.g. "db.table: users, db.id: 42".
 func (v *Value) String() string {
-	return fmt.Sprintf("%s: %s", v.Label, v.Value)
+	if v.Kind == KindGroup {
+		flat := v.Flatten()
+		s := make([]string, len(flat))
+		for i, v := range flat {
+			s[i] = v.String()
+		}
+		return strings.Join(s, ", ")
+	}
+	return fmt.Sprintf("%s: %s", v.Label, v.Text())
 }
 
 // String returns Value.
@@ -91,14 +175,17 @@ var hexPrefix = []byte("0x")
 var digits = []byte{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'a', 'b', 'c', 'd', 'e', 'f'}
 
 // Bytes returns Value.
+//
+// It is rendered by BytesFormat of the config when it is added to the error,
+// and lazily as hex by Text until then, so the field Value is empty until it is added.
+// `v` is copied, so it can be modified after the call.
 func Bytes(l string, v []byte) *Value {
-	buf := hexPrefix
-	for _, b := range v {
-		buf = append(buf, digits[b/16], digits[b%16])
-	}
+	b := append([]byte(nil), v...)
 	return &Value{
 		Label: l,
-		Value: string(buf),
+		Kind:  KindBytes,
+		lazy:  &lazyValue{fn: func() string { return formatHex(b) }},
+		bytes: b,
 	}
 }
 
@@ -312,6 +399,150 @@ func (ls Values) UTCTime(l string, v time.Time) Values {
 	return append(ls, UTCTime(l, v))
 }
 
+// Duration returns Value of duration.
+func Duration(l string, v time.Duration) *Value {
+	return &Value{
+		Label: l,
+		Value: v.String(),
+		Kind:  KindDuration,
+	}
+}
+
+// Duration appends duration Value and return Values.
+func (ls Values) Duration(l string, v time.Duration) Values {
+	return append(ls, Duration(l, v))
+}
+
+// ErrorValue returns Value of the error message.
+func ErrorValue(l string, v error) *Value {
+	value := &Value{
+		Label: l,
+		Value: "<nil>",
+		Kind:  KindError,
+	}
+	if v != nil {
+		value.Value = v.Error()
+	}
+	return value
+}
+
+// ErrorValue appends error Value and return Values.
+func (ls Values) ErrorValue(l string, v error) Values {
+	return append(ls, ErrorValue(l, v))
+}
+
+// IP returns Value of IP address.
+func IP(l string, v net.IP) *Value {
+	return &Value{
+		Label: l,
+		Value: v.String(),
+		Kind:  KindIP,
+	}
+}
+
+// IP appends IP address Value and return Values.
+func (ls Values) IP(l string, v net.IP) Values {
+	return append(ls, IP(l, v))
+}
+
+// Addr returns Value of IP address.
+func Addr(l string, v netip.Addr) *Value {
+	return &Value{
+		Label: l,
+		Value: v.String(),
+		Kind:  KindIP,
+	}
+}
+
+// Addr appends IP address Value and return Values.
+func (ls Values) Addr(l string, v netip.Addr) Values {
+	return append(ls, Addr(l, v))
+}
+
+// URL returns Value of URL.
+// The password is stripped from the URL.
+func URL(l string, v *url.URL) *Value {
+	value := &Value{
+		Label: l,
+		Value: "<nil>",
+		Kind:  KindURL,
+	}
+	if v != nil {
+		u := *v
+		if u.User != nil {
+			u.User = url.User(u.User.Username())
+		}
+		value.Value = u.String()
+	}
+	return value
+}
+
+// URL appends URL Value and return Values.
+func (ls Values) URL(l string, v *url.URL) Values {
+	return append(ls, URL(l, v))
+}
+
+// Strings returns Value of string slice, e.g. "[foo, bar]".
+// Structured encoders encode it as an array.
+func Strings(l string, v []string) *Value {
+	value := &Value{
+		Label: l,
+		Kind:  KindStrings,
+		Group: make([]*Value, len(v)),
+	}
+	for i, s := range v {
+		value.Group[i] = String(strconv.Itoa(i), s)
+	}
+	value.Value = joinMembers(value.Kind, value.Group)
+	return value
+}
+
+// Strings appends string slice Value and return Values.
+func (ls Values) Strings(l string, v []string) Values {
+	return append(ls, Strings(l, v))
+}
+
+// StringMap returns Value of string map sorted by keys, e.g. "{bar: 2, foo: 1}".
+// Structured encoders encode it as an object.
+func StringMap(l string, v map[string]string) *Value {
+	keys := make([]string, 0, len(v))
+	for k := range v {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	value := &Value{
+		Label: l,
+		Kind:  KindMap,
+		Group: make([]*Value, len(keys)),
+	}
+	for i, k := range keys {
+		value.Group[i] = String(k, v[k])
+	}
+	value.Value = joinMembers(value.Kind, value.Group)
+	return value
+}
+
+// joinMembers returns the text of string slice or string map Value has `members`.
+func joinMembers(kind Kind, members []*Value) string {
+	s := make([]string, len(members))
+	for i, m := range members {
+		if kind == KindMap {
+			s[i] = m.String()
+		} else {
+			s[i] = m.Text()
+		}
+	}
+	if kind == KindMap {
+		return "{" + strings.Join(s, ", ") + "}"
+	}
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+// StringMap appends string map Value and return Values.
+func (ls Values) StringMap(l string, v map[string]string) Values {
+	return append(ls, StringMap(l, v))
+}
+
 // DefaultStackDepth is the depth used when call Stack.
 var DefaultStackDepth = 16
 
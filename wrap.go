@@ -0,0 +1,93 @@
+package aerrors
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// wrappedFormat returns the format replaced `%w` verbs having error operands with `%v`, and the operands.
+//
+// If there are multiple error operands, wrappedError implements `Unwrap() []error`.
+func wrappedFormat(format string, args []interface{}) (newFormat string, wrappedError error) {
+	if strings.IndexByte(format, '%') < 0 {
+		return format, nil
+	}
+
+	b := []byte(format)
+	var wrapped []int
+	argNum := 0
+	for i := 0; i < len(b); i++ {
+		if b[i] != '%' {
+			continue
+		}
+		i++
+		if i < len(b) && b[i] == '%' {
+			continue
+		}
+		// flags
+		for i < len(b) && strings.IndexByte("+-# 0", b[i]) >= 0 {
+			i++
+		}
+		// argument index, width, precision and argument index before verb
+		for i < len(b) {
+			switch c := b[i]; {
+			case c == '[':
+				end := strings.IndexByte(format[i:], ']')
+				if end < 0 {
+					return string(b), joinWrapped(args, wrapped)
+				}
+				if n, err := strconv.Atoi(format[i+1 : i+end]); err == nil && n > 0 {
+					argNum = n - 1
+				}
+				i += end + 1
+				continue
+			case c == '*':
+				argNum++
+			case c == '.' || '0' <= c && c <= '9':
+			default:
+				goto verb
+			}
+			i++
+		}
+	verb:
+		if i >= len(b) {
+			break
+		}
+		// `%w` without the error operand is left to be formatted as a bad verb like fmt.Errorf.
+		if _, ok := argAt(args, argNum).(error); ok && b[i] == 'w' {
+			b[i] = 'v'
+			wrapped = append(wrapped, argNum)
+		}
+		argNum++
+	}
+
+	return string(b), joinWrapped(args, wrapped)
+}
+
+func argAt(args []interface{}, index int) interface{} {
+	if index < len(args) {
+		return args[index]
+	}
+	return nil
+}
+
+func joinWrapped(args []interface{}, indexes []int) error {
+	sort.Ints(indexes)
+	var errs []error
+	for i, index := range indexes {
+		if i > 0 && indexes[i-1] == index {
+			continue
+		}
+		errs = append(errs, args[index].(error))
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
+}
@@ -0,0 +1,110 @@
+package aerrors
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWrappedFormat(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	cases := []struct {
+		format      string
+		args        []interface{}
+		wantFormat  string
+		wantWrapped []error
+	}{
+		{
+			format:     "no verbs",
+			wantFormat: "no verbs",
+		},
+		{
+			format:      "error: %w",
+			args:        []interface{}{errA},
+			wantFormat:  "error: %v",
+			wantWrapped: []error{errA},
+		},
+		{
+			format:     "error: %w",
+			args:       []interface{}{42},
+			wantFormat: "error: %w",
+		},
+		{
+			format:     "error: %w",
+			wantFormat: "error: %w",
+		},
+		{
+			format:      "%w %w",
+			args:        []interface{}{errA, nil},
+			wantFormat:  "%v %w",
+			wantWrapped: []error{errA},
+		},
+		{
+			format:      "%w: detail %d",
+			args:        []interface{}{errA, 1},
+			wantFormat:  "%v: detail %d",
+			wantWrapped: []error{errA},
+		},
+		{
+			format:      "100%% %d %w",
+			args:        []interface{}{1, errA},
+			wantFormat:  "100%% %d %v",
+			wantWrapped: []error{errA},
+		},
+		{
+			format:      "%*d %-10w %.*f",
+			args:        []interface{}{5, 1, errA, 2, 3.14},
+			wantFormat:  "%*d %-10v %.*f",
+			wantWrapped: []error{errA},
+		},
+		{
+			format:      "%[2]w %[1]w %[2]w",
+			args:        []interface{}{errA, errB},
+			wantFormat:  "%[2]v %[1]v %[2]v",
+			wantWrapped: []error{errA, errB},
+		},
+		{
+			format:      "%w and %w",
+			args:        []interface{}{errB, errA},
+			wantFormat:  "%v and %v",
+			wantWrapped: []error{errB, errA},
+		},
+	}
+
+	for i, tc := range cases {
+		gotFormat, gotWrapped := wrappedFormat(tc.format, tc.args)
+		if gotFormat != tc.wantFormat {
+			t.Errorf("#%d: format == %#v, want %#v", i, gotFormat, tc.wantFormat)
+		}
+		var got []error
+		switch w := gotWrapped.(type) {
+		case nil:
+		case interface{ Unwrap() []error }:
+			got = w.Unwrap()
+		default:
+			got = []error{w}
+		}
+		if !reflect.DeepEqual(got, tc.wantWrapped) {
+			t.Errorf("#%d: wrapped == %v, want %v", i, got, tc.wantWrapped)
+		}
+	}
+}
+
+func TestErrorf_badWrap(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{err: Errorf("%w", 3), want: "%!w(int=3)"},
+		{err: Errorf("oops: %w"), want: "oops: %!w(MISSING)"},
+		{err: Errorf("%w: %w", errors.New("a"), "b"), want: "a: %!w(string=b)"},
+	}
+
+	for i, tc := range cases {
+		if got := tc.err.Error(); got != tc.want {
+			t.Errorf("#%d: Error() == %#v, want %#v", i, got, tc.want)
+		}
+	}
+}